@@ -0,0 +1,351 @@
+package commands
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// AuthorizationRule defines who may execute commands with names that match a pattern.
+//
+//	pattern - a command name or a glob pattern with "*" and "?" wildcards, like "get_*"
+//	roles - the caller must have at least one of the roles, any role if empty
+//	claims - the caller must have all the claims, "*" values match any claim value
+//	anonymous - true to allow calls without caller principal
+//	see AuthorizationInterceptor
+type AuthorizationRule struct {
+	Pattern   string
+	Roles     []string
+	Claims    map[string]string
+	Anonymous bool
+
+	regex *regexp.Regexp
+}
+
+// NewAuthorizationRule creates a new rule for commands that match the pattern.
+// Without roles and claims the rule allows any authenticated caller.
+//	Parameters: pattern string a command name or a glob pattern
+//	Returns: *AuthorizationRule
+func NewAuthorizationRule(pattern string) *AuthorizationRule {
+	if pattern == "" {
+		panic("Pattern cannot be empty")
+	}
+
+	return &AuthorizationRule{
+		Pattern: pattern,
+		Claims:  map[string]string{},
+		regex:   compileGlob(pattern),
+	}
+}
+
+// WithRoles adds roles, one of which the caller must have.
+//	Parameters: roles ...string allowed roles
+//	Returns: *AuthorizationRule
+func (c *AuthorizationRule) WithRoles(roles ...string) *AuthorizationRule {
+	c.Roles = append(c.Roles, roles...)
+	return c
+}
+
+// WithClaim adds a claim the caller must have.
+//	Parameters:
+//		- key string a claim key
+//		- value string a required claim value or "*" to accept any value
+//	Returns: *AuthorizationRule
+func (c *AuthorizationRule) WithClaim(key string, value string) *AuthorizationRule {
+	c.Claims[key] = value
+	return c
+}
+
+// WithAnonymous allows calls without caller principal.
+//	Returns: *AuthorizationRule
+func (c *AuthorizationRule) WithAnonymous() *AuthorizationRule {
+	c.Anonymous = true
+	return c
+}
+
+// Matches checks if the rule applies to the command.
+//	Parameters: command string a command name
+//	Returns: bool true if the command name matches the rule pattern
+func (c *AuthorizationRule) Matches(command string) bool {
+	return c.regex.MatchString(command)
+}
+
+// specificity ranks rules with more literal characters and fewer wildcards higher.
+func (c *AuthorizationRule) specificity() (int, int) {
+	wildcards := strings.Count(c.Pattern, "*") + strings.Count(c.Pattern, "?")
+	return len(c.Pattern) - wildcards, -wildcards
+}
+
+// check verifies the caller principal against the rule.
+func (c *AuthorizationRule) check(correlationId string, command string, principal *run.Principal) error {
+	if principal == nil {
+		if c.Anonymous {
+			return nil
+		}
+		return errors.NewUnauthorizedError(correlationId, "NOT_AUTHENTICATED",
+			"Command "+command+" requires authenticated caller").
+			WithDetails("command", command)
+	}
+
+	if len(c.Roles) > 0 {
+		allowed := false
+		for _, role := range c.Roles {
+			if principal.HasRole(role) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return errors.NewForbiddenError(correlationId, "COMMAND_ACCESS_DENIED",
+				"Caller "+principal.Id+" has no role to execute command "+command).
+				WithDetails("command", command).
+				WithDetails("principal", principal.Id).
+				WithDetails("roles", strings.Join(c.Roles, ","))
+		}
+	}
+
+	keys := make([]string, 0, len(c.Claims))
+	for key := range c.Claims {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		expected := c.Claims[key]
+		value, ok := principal.GetClaim(key)
+		if !ok || (expected != "*" && !claimContains(value, expected)) {
+			return errors.NewForbiddenError(correlationId, "COMMAND_ACCESS_DENIED",
+				"Caller "+principal.Id+" has no claim "+key+" to execute command "+command).
+				WithDetails("command", command).
+				WithDetails("principal", principal.Id).
+				WithDetails("claim", key).
+				WithDetails("value", expected)
+		}
+	}
+
+	return nil
+}
+
+// claimContains checks if a claim value equals the expected value or contains it when the claim is a list.
+func claimContains(value any, expected string) bool {
+	switch v := value.(type) {
+	case []string:
+		for _, item := range v {
+			if item == expected {
+				return true
+			}
+		}
+		return false
+	case []any:
+		for _, item := range v {
+			if convert.StringConverter.ToString(item) == expected {
+				return true
+			}
+		}
+		return false
+	default:
+		return convert.StringConverter.ToString(value) == expected
+	}
+}
+
+// AuthorizationInterceptor checks that a caller may execute commands.
+// The caller roles and claims are read from run.Principal in the context.
+//
+// Rules are matched by registered command names, so all versions and aliases of a command share its rules.
+// When several rules match a command, the most specific one is applied: the one with more
+// literal characters and fewer wildcards. Commands without matching rules are denied
+// unless unmatched commands are allowed.
+// Failures are returned as UnauthorizedError with "NOT_AUTHENTICATED" code for anonymous callers
+// of commands that require authentication, and as ForbiddenError with "COMMAND_ACCESS_DENIED" code
+// for callers without required roles or claims and for denied unmatched commands.
+//
+// The interceptor implements ICommandAuthorizer, so CommandSet checks authorization
+// before it validates command arguments. Execute checks only commands called through the chain directly,
+// commands already authorized by CommandSet are not checked again.
+//
+// Configuration parameters:
+//
+//	authorization.allow_unmatched: true to allow commands without matching rules (default: false)
+//	authorization.rules.<pattern>.roles: comma-separated list of allowed roles
+//	authorization.rules.<pattern>.claims.<key>: a required claim value or "*" for any value
+//	authorization.rules.<pattern>.anonymous: true to allow anonymous callers
+//
+// Configured rules replace rules with the same pattern declared in code.
+// Patterns in configuration cannot contain dots.
+//	see run.WithPrincipal
+//	Example:
+//		interceptor := NewAuthorizationInterceptor().
+//			WithRule(NewAuthorizationRule("get_*").WithRoles("user", "admin")).
+//			WithRule(NewAuthorizationRule("delete_*").WithRoles("admin").WithClaim("scope", "orders:write")).
+//			WithRule(NewAuthorizationRule("ping").WithAnonymous())
+//		commandSet.AddInterceptor(interceptor)
+//
+//		ctx = run.WithPrincipal(ctx, run.NewPrincipal("user1", "user"))
+//		commandSet.Execute(ctx, "123", "get_orders", args)   // allowed
+//		commandSet.Execute(ctx, "123", "delete_order", args) // returns COMMAND_ACCESS_DENIED error
+type AuthorizationInterceptor struct {
+	interceptorBase
+	rulesMtx       sync.RWMutex
+	rules          []*AuthorizationRule
+	allowUnmatched bool
+}
+
+// NewAuthorizationInterceptor creates a new authorization interceptor without rules.
+//	Returns: *AuthorizationInterceptor
+func NewAuthorizationInterceptor() *AuthorizationInterceptor {
+	return &AuthorizationInterceptor{
+		rules: []*AuthorizationRule{},
+	}
+}
+
+// WithRule adds an authorization rule. A rule with the same pattern is replaced.
+//	Parameters: rule *AuthorizationRule a rule to add
+//	Returns: *AuthorizationInterceptor
+func (c *AuthorizationInterceptor) WithRule(rule *AuthorizationRule) *AuthorizationInterceptor {
+	if rule == nil {
+		panic("Rule cannot be nil")
+	}
+
+	c.rulesMtx.Lock()
+	defer c.rulesMtx.Unlock()
+
+	c.addRule(rule)
+	return c
+}
+
+func (c *AuthorizationInterceptor) addRule(rule *AuthorizationRule) {
+	for index, r := range c.rules {
+		if r.Pattern == rule.Pattern {
+			c.rules[index] = rule
+			return
+		}
+	}
+	c.rules = append(c.rules, rule)
+}
+
+// WithAllowUnmatched sets if commands without matching rules are allowed.
+//	Parameters: allow bool true to allow commands without matching rules
+//	Returns: *AuthorizationInterceptor
+func (c *AuthorizationInterceptor) WithAllowUnmatched(allow bool) *AuthorizationInterceptor {
+	c.rulesMtx.Lock()
+	defer c.rulesMtx.Unlock()
+
+	c.allowUnmatched = allow
+	return c
+}
+
+// Configure configures the interceptor and reads authorization rules.
+//	Parameters:
+//		- ctx context.Context
+//		- config: *config.ConfigParams configuration parameters to be set.
+func (c *AuthorizationInterceptor) Configure(ctx context.Context, config *config.ConfigParams) {
+	c.interceptorBase.Configure(ctx, config)
+
+	c.rulesMtx.Lock()
+	defer c.rulesMtx.Unlock()
+
+	c.allowUnmatched = config.GetAsBooleanWithDefault("authorization.allow_unmatched", c.allowUnmatched)
+
+	rules := config.GetSection("authorization.rules")
+	for _, pattern := range rules.GetSectionNames() {
+		section := rules.GetSection(pattern)
+		rule := NewAuthorizationRule(pattern)
+
+		for _, role := range strings.Split(section.GetAsString("roles"), ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				rule.WithRoles(role)
+			}
+		}
+		claims := section.GetSection("claims")
+		for _, key := range claims.Keys() {
+			rule.WithClaim(key, claims.GetAsString(key))
+		}
+		if section.GetAsBoolean("anonymous") {
+			rule.WithAnonymous()
+		}
+
+		c.addRule(rule)
+	}
+}
+
+// FindRule finds the most specific rule that matches the command.
+//	Parameters: command string a command name
+//	Returns: *AuthorizationRule the matching rule or nil if no rules match the command
+func (c *AuthorizationInterceptor) FindRule(command string) *AuthorizationRule {
+	c.rulesMtx.RLock()
+	defer c.rulesMtx.RUnlock()
+
+	var result *AuthorizationRule
+	for _, rule := range c.rules {
+		if !rule.Matches(command) {
+			continue
+		}
+		if result == nil {
+			result = rule
+			continue
+		}
+
+		literals, wildcards := rule.specificity()
+		resultLiterals, resultWildcards := result.specificity()
+		if literals > resultLiterals || (literals == resultLiterals && wildcards > resultWildcards) {
+			result = rule
+		}
+	}
+	return result
+}
+
+// Authorize checks if the caller in the context may execute the command.
+//	Parameters:
+//		- ctx context.Context a context with caller principal
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- command: string a command name
+//	Returns: error UnauthorizedError or ForbiddenError if the caller may not execute the command or nil
+func (c *AuthorizationInterceptor) Authorize(ctx context.Context, correlationId string, command string) error {
+	principal := run.GetPrincipal(ctx)
+
+	rule := c.FindRule(command)
+	if rule == nil {
+		c.rulesMtx.RLock()
+		allowUnmatched := c.allowUnmatched
+		c.rulesMtx.RUnlock()
+
+		if allowUnmatched {
+			return nil
+		}
+
+		err := errors.NewForbiddenError(correlationId, "COMMAND_ACCESS_DENIED",
+			"Command "+command+" is not allowed").
+			WithDetails("command", command)
+		if principal != nil {
+			err.WithDetails("principal", principal.Id)
+		}
+		return err
+	}
+
+	return rule.check(correlationId, command, principal)
+}
+
+// Execute checks the caller authorization and executes the command.
+//	Parameters:
+//		- ctx context.Context a context with caller principal
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- command: ICommand the next command in the call chain
+//		- args: *run.Parameters the parameters (arguments) to pass to the command
+//	Returns: (any, error) the execution result or UnauthorizedError or ForbiddenError
+func (c *AuthorizationInterceptor) Execute(ctx context.Context, correlationId string, command ICommand,
+	args *run.Parameters) (any, error) {
+
+	if !isAuthorizedCommand(ctx, command.Name()) {
+		if err := c.Authorize(ctx, correlationId, command.Name()); err != nil {
+			return nil, err
+		}
+	}
+	return command.Execute(ctx, correlationId, args)
+}
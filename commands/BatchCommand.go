@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// BatchMode defines how CommandSet.ExecuteBatch runs commands.
+//	Possible values:
+//		- BatchSequential - commands are executed one by one, failures do not stop the batch
+//		- BatchParallel - commands are executed in parallel, failures do not stop the batch
+//		- BatchAllOrNothing - commands are executed one by one, on the first failure the rest
+//			are skipped and executed commands that implement ICompensable are rolled back in reverse order
+type BatchMode int
+
+const (
+	BatchSequential BatchMode = iota
+	BatchParallel
+	BatchAllOrNothing
+)
+
+// BatchCommand is a command call in a batch.
+//
+//	command - a name of the command to execute
+//	args - command arguments
+type BatchCommand struct {
+	Command string          `json:"command"`
+	Args    *run.Parameters `json:"args"`
+}
+
+// NewBatchCommand creates a new command call for a batch.
+//	Parameters:
+//		- command: string a command name
+//		- args: *run.Parameters command arguments
+//	Returns: *BatchCommand
+func NewBatchCommand(command string, args *run.Parameters) *BatchCommand {
+	return &BatchCommand{
+		Command: command,
+		Args:    args,
+	}
+}
+
+// BatchResult is a result of a command call in a batch.
+//
+//	command - a name of the executed command
+//	result - an execution result
+//	err - an execution error, nil if the command succeeded
+//	executed - true if the command was executed
+//	compensated - true if the execution was rolled back
+type BatchResult struct {
+	Command     string
+	Result      any
+	Err         error
+	Executed    bool
+	Compensated bool
+}
@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// CircuitState defines states of a circuit breaker.
+//	Possible values:
+//		- CircuitClosed - commands are executed normally
+//		- CircuitOpen - commands are rejected without execution
+//		- CircuitHalfOpen - a single trial execution is allowed to check if the command recovered
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+// CircuitBreakerInterceptor stops executing commands that keep failing.
+// After the configured number of consecutive failures the circuit opens and
+// executions are rejected with retryable ConnectionError with "CIRCUIT_OPEN" code
+// and 503 (Service Unavailable) status.
+// After the reset timeout a single trial execution is allowed: when it succeeds
+// the circuit closes, otherwise it opens again. The trial is executed with a deadline
+// set in its context, and a trial that panics or does not complete before the deadline
+// is counted as a failure, so it cannot keep the circuit half-open.
+//
+// Only server-side errors are counted as failures. ApplicationErrors with statuses below 500,
+// like BadRequestError or NotFoundError, are caused by clients and do not open the circuit.
+//
+// Configuration parameters:
+//
+//	circuit_breaker.failure_threshold: number of consecutive failures to open the circuit, 0 to disable (default: 5)
+//	circuit_breaker.reset_timeout: time in milliseconds before a trial execution (default: 30000)
+//	circuit_breaker.trial_timeout: time in milliseconds to complete a trial execution (default: 30000)
+//	commands.<name>.circuit_breaker.*: circuit breaker parameters for specific command
+//
+//	Example:
+//		interceptor := NewCircuitBreakerInterceptor()
+//		interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+//			"circuit_breaker.failure_threshold", 3,
+//			"circuit_breaker.reset_timeout", 10000,
+//		))
+//		commandSet.AddInterceptor(interceptor)
+type CircuitBreakerInterceptor struct {
+	interceptorBase
+	mtx      sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state          CircuitState
+	failures       int
+	openedAt       time.Time
+	trial          int
+	trialStartedAt time.Time
+}
+
+// NewCircuitBreakerInterceptor creates a new circuit breaker interceptor.
+//	Returns: *CircuitBreakerInterceptor
+func NewCircuitBreakerInterceptor() *CircuitBreakerInterceptor {
+	return &CircuitBreakerInterceptor{
+		circuits: map[string]*circuit{},
+	}
+}
+
+// GetState gets the current state of the circuit for the command.
+//	Parameters: name string a command name
+//	Returns: CircuitState
+func (c *CircuitBreakerInterceptor) GetState(name string) CircuitState {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if circuit, ok := c.circuits[name]; ok {
+		return circuit.state
+	}
+	return CircuitClosed
+}
+
+// Reset closes the circuit for the command.
+//	Parameters: name string a command name
+func (c *CircuitBreakerInterceptor) Reset(name string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.circuits, name)
+}
+
+// Execute executes the command when the circuit is closed.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- command: ICommand the next command in the call chain
+//		- args: *run.Parameters the parameters (arguments) to pass to the command
+//	Returns: (any, error) the execution result
+func (c *CircuitBreakerInterceptor) Execute(ctx context.Context, correlationId string, command ICommand,
+	args *run.Parameters) (any, error) {
+
+	name := command.Name()
+	config := c.commandConfig(name)
+	threshold := config.GetAsIntegerWithDefault("circuit_breaker.failure_threshold", 5)
+	resetTimeout := config.GetAsLongWithDefault("circuit_breaker.reset_timeout", 30000)
+	trialTimeout := config.GetAsLongWithDefault("circuit_breaker.trial_timeout", 30000)
+
+	if threshold <= 0 {
+		return command.Execute(ctx, correlationId, args)
+	}
+
+	trial, wait, ok := c.acquire(name, resetTimeout, trialTimeout)
+	if !ok {
+		return nil, errors.NewConnectionError(
+			correlationId,
+			"CIRCUIT_OPEN",
+			"Circuit for command "+name+" is open",
+		).WithStatus(503).
+			WithDetails("command", name).
+			WithRetryable(true).
+			WithRetryAfter(wait)
+	}
+
+	if trial > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(trialTimeout)*time.Millisecond)
+		defer cancel()
+	}
+
+	// Panics leave failed set, so they are counted as failures
+	failed := true
+	defer func() {
+		c.release(name, threshold, trial, failed)
+	}()
+
+	result, err := command.Execute(ctx, correlationId, args)
+	failed = isCircuitFailure(err)
+	return result, err
+}
+
+// acquire checks if execution is allowed. It returns a non-zero trial number for trial executions,
+// and for open circuits it returns time in milliseconds until a trial execution is allowed.
+func (c *CircuitBreakerInterceptor) acquire(name string, resetTimeout int64, trialTimeout int64) (int, int64, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	circuit, ok := c.circuits[name]
+	if !ok {
+		return 0, 0, true
+	}
+
+	switch circuit.state {
+	case CircuitOpen:
+		elapsed := time.Since(circuit.openedAt).Milliseconds()
+		if elapsed < resetTimeout {
+			return 0, resetTimeout - elapsed, false
+		}
+		circuit.state = CircuitHalfOpen
+		circuit.trial++
+		circuit.trialStartedAt = time.Now()
+		return circuit.trial, 0, true
+	case CircuitHalfOpen:
+		// A trial that did not complete in time is counted as failure
+		if time.Since(circuit.trialStartedAt).Milliseconds() >= trialTimeout {
+			circuit.state = CircuitOpen
+			circuit.failures++
+			circuit.openedAt = time.Now()
+		}
+		// Only one trial execution is allowed
+		return 0, resetTimeout, false
+	default:
+		return 0, 0, true
+	}
+}
+
+func (c *CircuitBreakerInterceptor) release(name string, threshold int, trial int, failed bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	current, ok := c.circuits[name]
+	// Results of expired trials are ignored
+	if trial > 0 && (!ok || current.state != CircuitHalfOpen || current.trial != trial) {
+		return
+	}
+	if !ok {
+		if !failed {
+			return
+		}
+		current = &circuit{}
+		c.circuits[name] = current
+	}
+
+	if !failed {
+		current.state = CircuitClosed
+		current.failures = 0
+		return
+	}
+
+	current.failures++
+	if current.state == CircuitHalfOpen || current.failures >= threshold {
+		current.state = CircuitOpen
+		current.openedAt = time.Now()
+	}
+}
+
+func isCircuitFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var appErr *errors.ApplicationError
+	if errors.As(err, &appErr) && appErr.Status > 0 && appErr.Status < 500 {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,447 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+// OutputFormat defines how CommandLine prints command results.
+//	Possible values:
+//		- JsonOutput - results are printed as indented JSON
+//		- TableOutput - objects are printed as key/value tables, arrays of objects as tables with a column per key
+type OutputFormat string
+
+const (
+	JsonOutput  OutputFormat = "json"
+	TableOutput OutputFormat = "table"
+)
+
+// CommandLine is a command-line runtime that calls commands of a CommandSet.
+//
+// Arguments are passed as named options or as a JSON object:
+//
+//	<program> [--format json|table] [--correlation_id <id>] <command> [--<key> <value> ...] [<json>]
+//	<program> help [<command>]
+//	<program> <command> --help
+//
+// Global options like --format and --correlation_id must be placed before the command name,
+// all options after the command name are passed to the command as its arguments.
+// Option values are converted into types defined by the command schema, values of options
+// that are not described by the schema are kept as strings. Keys can use dot notation
+// to set nested values, like "--filter.name john". Options without values are set to true.
+// Arguments are validated with the command schema before execution.
+// Errors are printed to the error output and converted into exit codes
+// by their categories using errors.ErrorStatusMapping.
+//	Example:
+//		func main() {
+//			commandSet := NewOrdersCommandSet()
+//			cli := commands.NewCommandLine("orders", commandSet)
+//			os.Exit(cli.Run(context.Background(), os.Args[1:]))
+//		}
+//
+//		// orders --format table get_orders --filter.status paid
+//		// orders create_order '{"customer_id": "1", "total": 10.5}'
+type CommandLine struct {
+	program    string
+	commandSet *CommandSet
+	format     OutputFormat
+	out        io.Writer
+	errOut     io.Writer
+}
+
+// NewCommandLine creates a new command-line runtime for the command set.
+// Results are printed as JSON to the standard output, errors to the standard error.
+//	Parameters:
+//		- program: string a program name used in help
+//		- commandSet: *CommandSet a command set to call
+//	Returns: *CommandLine
+func NewCommandLine(program string, commandSet *CommandSet) *CommandLine {
+	if commandSet == nil {
+		panic("Command set cannot be nil")
+	}
+
+	return &CommandLine{
+		program:    program,
+		commandSet: commandSet,
+		format:     JsonOutput,
+		out:        os.Stdout,
+		errOut:     os.Stderr,
+	}
+}
+
+// WithFormat sets a default format of command results.
+//	Parameters: format OutputFormat an output format
+//	Returns: *CommandLine
+func (c *CommandLine) WithFormat(format OutputFormat) *CommandLine {
+	c.format = format
+	return c
+}
+
+// WithOutput sets writers for results and errors.
+//	Parameters:
+//		- out io.Writer a writer for results and help
+//		- errOut io.Writer a writer for errors
+//	Returns: *CommandLine
+func (c *CommandLine) WithOutput(out io.Writer, errOut io.Writer) *CommandLine {
+	c.out = out
+	c.errOut = errOut
+	return c
+}
+
+// Run parses command-line arguments, executes the command and prints its result.
+//	Parameters:
+//		- ctx context.Context
+//		- args: []string command-line arguments without the program name
+//	Returns: int a process exit code, 0 if the command succeeded
+func (c *CommandLine) Run(ctx context.Context, args []string) int {
+	format := c.format
+	correlationId := ""
+
+	// Parse global options
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		option, value, hasValue := strings.Cut(strings.TrimLeft(args[0], "-"), "=")
+		args = args[1:]
+
+		switch option {
+		case "h", "help":
+			fmt.Fprint(c.out, c.Help(""))
+			return errors.ExitOk
+		case "format", "correlation_id":
+			if !hasValue {
+				if len(args) == 0 {
+					return c.printError(errors.NewBadRequestError("", "INVALID_ARGS",
+						"Option "+option+" requires a value").WithDetails("option", option))
+				}
+				value, args = args[0], args[1:]
+			}
+			if option == "format" {
+				format = OutputFormat(value)
+			} else {
+				correlationId = value
+			}
+		default:
+			return c.printError(errors.NewBadRequestError("", "INVALID_ARGS",
+				"Unknown option "+option).WithDetails("option", option))
+		}
+	}
+
+	if format != JsonOutput && format != TableOutput {
+		return c.printError(errors.NewBadRequestError(correlationId, "INVALID_FORMAT",
+			"Unsupported output format "+string(format)).WithDetails("format", format))
+	}
+
+	if len(args) == 0 {
+		fmt.Fprint(c.errOut, c.Help(""))
+		return errors.ExitUsage
+	}
+
+	name := args[0]
+	if name == "help" {
+		if len(args) > 1 {
+			name = args[1]
+		} else {
+			name = ""
+		}
+		return c.printHelp(correlationId, name)
+	}
+	for _, arg := range args[1:] {
+		if arg == "--help" || arg == "-h" {
+			return c.printHelp(correlationId, name)
+		}
+	}
+
+	parameters, err := c.Parse(correlationId, name, args[1:])
+	if err != nil {
+		return c.printError(err)
+	}
+
+	result, err := c.commandSet.Execute(ctx, correlationId, name, parameters)
+	if err != nil {
+		return c.printError(err)
+	}
+
+	if err = c.printResult(format, result); err != nil {
+		return c.printError(errors.NewInternalError(correlationId, "OUTPUT_FAILED",
+			"Failed to print command result").WithCause(err))
+	}
+	return errors.ExitOk
+}
+
+// Parse converts command-line arguments of a command into parameters.
+// Arguments can be named options "--key value", "--key=value" or a JSON object.
+// Option values are converted into types defined by the command schema.
+//	Parameters:
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- command: string a command name
+//		- args: []string command arguments
+//	Returns: (*run.Parameters, error) parsed parameters or BadRequestError if arguments are invalid
+func (c *CommandLine) Parse(correlationId string, command string, args []string) (*run.Parameters, error) {
+	jsonSchema := map[string]any{}
+	if version := c.commandSet.resolveCommand(command); version != nil {
+		if schema := commandSchema(version.command); schema != nil {
+			jsonSchema = validate.JsonSchemaConverter.ToJsonSchema(schema)
+		}
+	}
+
+	parameters := run.NewEmptyParameters()
+	for index := 0; index < len(args); index++ {
+		arg := args[index]
+
+		if !strings.HasPrefix(arg, "--") {
+			values := map[string]any{}
+			if err := json.Unmarshal([]byte(arg), &values); err != nil {
+				return nil, errors.NewBadRequestError(correlationId, "INVALID_ARGS",
+					"Argument "+arg+" is not a JSON object").
+					WithDetails("argument", arg).
+					WithCause(err)
+			}
+			parameters = parameters.Override(run.NewParameters(values), true)
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(arg[2:], "=")
+		if key == "" {
+			return nil, errors.NewBadRequestError(correlationId, "INVALID_ARGS",
+				"Option name is missing").WithDetails("argument", arg)
+		}
+		if !hasValue {
+			if index+1 < len(args) && !strings.HasPrefix(args[index+1], "--") {
+				index++
+				value = args[index]
+			} else {
+				value = "true"
+			}
+		}
+
+		parameters.Put(key, parseOptionValue(value, findPropertyJsonSchema(jsonSchema, key)))
+	}
+
+	return parameters, nil
+}
+
+// findPropertyJsonSchema finds JSON Schema of a property by a path in dot notation.
+func findPropertyJsonSchema(schema map[string]any, path string) map[string]any {
+	for _, name := range strings.Split(path, ".") {
+		properties, ok := schema["properties"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		if schema, ok = properties[name].(map[string]any); !ok {
+			return nil
+		}
+	}
+	return schema
+}
+
+// parseOptionValue converts a string value into a type defined by JSON Schema.
+// Values of objects and arrays are parsed as JSON when possible, values without schema are kept as strings.
+func parseOptionValue(value string, schema map[string]any) any {
+	typ, _ := schema["type"].(string)
+
+	var converted any
+	ok := false
+	switch typ {
+	case "string":
+		return value
+	case "boolean":
+		converted, ok = convert.TypeConverter.ToNullableType(convert.Boolean, value)
+	case "integer":
+		converted, ok = convert.TypeConverter.ToNullableType(convert.Long, value)
+	case "number":
+		converted, ok = convert.TypeConverter.ToNullableType(convert.Double, value)
+	case "object", "array":
+		ok = json.Unmarshal([]byte(value), &converted) == nil
+	default:
+		return value
+	}
+
+	if !ok {
+		// Invalid values are reported by validation
+		return value
+	}
+	return converted
+}
+
+// Help generates help text for a command or a list of commands if the name is empty.
+//	Parameters: command string a command name or empty string
+//	Returns: string help text or empty string if the command is not found
+func (c *CommandLine) Help(command string) string {
+	exporter := NewCommandSetExporter(c.commandSet)
+	builder := strings.Builder{}
+
+	if command == "" {
+		builder.WriteString("Usage: " + c.program + " [--format json|table] [--correlation_id <id>] <command> [--<key> <value> ...] [<json>]\n")
+		builder.WriteString("       " + c.program + " help <command>\n\nCommands:\n")
+
+		writer := tabwriter.NewWriter(&builder, 0, 4, 2, ' ', 0)
+		for _, exported := range exporter.sortedCommands() {
+			description := ""
+			if exported.metadata.IsDeprecated() {
+				description = describeDeprecation(exported.metadata.Deprecation)
+			}
+			fmt.Fprintf(writer, "  %s\t%s\n", exported.name, description)
+		}
+		writer.Flush()
+		return builder.String()
+	}
+
+	version := c.commandSet.resolveCommand(command)
+	if version == nil {
+		return ""
+	}
+
+	builder.WriteString("Usage: " + c.program + " " + command + " [--<key> <value> ...] [<json>]\n")
+	if version.metadata.IsDeprecated() {
+		builder.WriteString("\n" + describeDeprecation(version.metadata.Deprecation) + "\n")
+	}
+
+	schema := exporter.argsJsonSchema(version.command)
+	properties, _ := schema["properties"].(map[string]any)
+	if len(properties) == 0 {
+		builder.WriteString("\nNo arguments defined.\n")
+		return builder.String()
+	}
+
+	required := map[string]bool{}
+	if names, ok := schema["required"].([]string); ok {
+		for _, name := range names {
+			required[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	builder.WriteString("\nArguments:\n")
+	writer := tabwriter.NewWriter(&builder, 0, 4, 2, ' ', 0)
+	for _, name := range names {
+		property, _ := properties[name].(map[string]any)
+		typ, constraints := describeJsonSchema(property)
+		requiredText := "optional"
+		if required[name] {
+			requiredText = "required"
+		}
+		fmt.Fprintf(writer, "  --%s\t%s\t%s\t%s\n", name, typ, requiredText, strings.ReplaceAll(constraints, "`", ""))
+	}
+	writer.Flush()
+	return builder.String()
+}
+
+func (c *CommandLine) printHelp(correlationId string, command string) int {
+	help := c.Help(command)
+	if help == "" {
+		return c.printError(errors.NewBadRequestError(correlationId, "CMD_NOT_FOUND",
+			"Request command does not exist").WithDetails("command", command))
+	}
+	fmt.Fprint(c.out, help)
+	return errors.ExitOk
+}
+
+func (c *CommandLine) printError(err error) int {
+	description := errors.ErrorDescriptionFactory.CreateExternal(err)
+	fmt.Fprintf(c.errOut, "Error %s: %s\n", description.Code, description.Message)
+	for _, key := range sortedKeys(description.Details) {
+		fmt.Fprintf(c.errOut, "  %s: %v\n", key, description.Details[key])
+	}
+	return errors.ErrorStatusMapping.ExitCodeOf(err)
+}
+
+func (c *CommandLine) printResult(format OutputFormat, result any) error {
+	if result == nil {
+		return nil
+	}
+
+	if format == JsonOutput {
+		buffer, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(c.out, string(buffer))
+		return err
+	}
+
+	// Convert structs into maps and slices to print them uniformly
+	buffer, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	var value any
+	if err = json.Unmarshal(buffer, &value); err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(c.out, 0, 4, 2, ' ', 0)
+	switch v := value.(type) {
+	case map[string]any:
+		keys := sortedKeys(v)
+		for _, key := range keys {
+			fmt.Fprintf(writer, "%s\t%s\n", key, formatTableValue(v[key]))
+		}
+	case []any:
+		columns := map[string]bool{}
+		for _, item := range v {
+			if row, ok := item.(map[string]any); ok {
+				for key := range row {
+					columns[key] = true
+				}
+			}
+		}
+		if len(columns) == 0 {
+			for _, item := range v {
+				fmt.Fprintln(writer, formatTableValue(item))
+			}
+			break
+		}
+
+		keys := sortedKeys(columns)
+		fmt.Fprintln(writer, strings.ToUpper(strings.Join(keys, "\t")))
+		for _, item := range v {
+			row, _ := item.(map[string]any)
+			cells := make([]string, len(keys))
+			for index, key := range keys {
+				cells[index] = formatTableValue(row[key])
+			}
+			fmt.Fprintln(writer, strings.Join(cells, "\t"))
+		}
+	default:
+		fmt.Fprintln(writer, formatTableValue(v))
+	}
+	return writer.Flush()
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatTableValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		buffer, _ := json.Marshal(v)
+		return string(buffer)
+	default:
+		return convert.StringConverter.ToString(v)
+	}
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// CommandMetadata defines a version, aliases and deprecation of a command registered in CommandSet.
+//
+//	version - a command version starting from 1. Commands with the same name and different versions
+//		are called as "name@v2". Calls without version resolve the latest version.
+//	aliases - alternative names of this command version, for instance its names before renaming
+//	deprecation - deprecation details or nil if the command is not deprecated
+//	see CommandSet.AddVersionedCommand
+//	Example:
+//		commandSet.AddVersionedCommand(getItemsV1, NewCommandMetadata(1).
+//			WithAliases("get_data").
+//			WithDeprecation("2.0", "get_items@v2", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+//		commandSet.AddVersionedCommand(getItemsV2, NewCommandMetadata(2))
+//
+//		commandSet.Execute(ctx, "123", "get_items", args)    // executes version 2
+//		commandSet.Execute(ctx, "123", "get_items@v1", args) // executes version 1
+//		commandSet.Execute(ctx, "123", "get_data", args)     // executes version 1 by alias
+type CommandMetadata struct {
+	Version     int                 `json:"version"`
+	Aliases     []string            `json:"aliases,omitempty"`
+	Deprecation *CommandDeprecation `json:"deprecation,omitempty"`
+}
+
+// CommandDeprecation describes deprecation of a command.
+//
+//	since - a version or a date since the command is deprecated
+//	replacement - a name of the command to use instead, for instance "get_items@v2"
+//	sunset - a time when the command is going to be removed, zero if it is not defined
+type CommandDeprecation struct {
+	Since       string    `json:"since,omitempty"`
+	Replacement string    `json:"replacement,omitempty"`
+	Sunset      time.Time `json:"sunset,omitempty"`
+}
+
+// DeprecatedCommandHandler is a callback that is called when a deprecated command is executed.
+// The command name is the name used by the caller, for instance an alias or "name@v1".
+type DeprecatedCommandHandler func(ctx context.Context, correlationId string, command string, metadata *CommandMetadata)
+
+// NewCommandMetadata creates metadata for the command version.
+//	Parameters: version int a command version, values less than 1 are replaced with 1
+//	Returns: *CommandMetadata
+func NewCommandMetadata(version int) *CommandMetadata {
+	if version < 1 {
+		version = 1
+	}
+	return &CommandMetadata{
+		Version: version,
+	}
+}
+
+// WithAliases adds alternative names of the command.
+//	Parameters: aliases ...string alternative names
+//	Returns: *CommandMetadata
+func (c *CommandMetadata) WithAliases(aliases ...string) *CommandMetadata {
+	c.Aliases = append(c.Aliases, aliases...)
+	return c
+}
+
+// WithDeprecation marks the command as deprecated.
+//	Parameters:
+//		- since string a version or a date since the command is deprecated
+//		- replacement string a name of the command to use instead
+//		- sunset time.Time a time when the command is going to be removed, zero if it is not defined
+//	Returns: *CommandMetadata
+func (c *CommandMetadata) WithDeprecation(since string, replacement string, sunset time.Time) *CommandMetadata {
+	c.Deprecation = &CommandDeprecation{
+		Since:       since,
+		Replacement: replacement,
+		Sunset:      sunset,
+	}
+	return c
+}
+
+// IsDeprecated checks if the command is deprecated.
+//	Returns: bool true if the command is deprecated
+func (c *CommandMetadata) IsDeprecated() bool {
+	return c != nil && c.Deprecation != nil
+}
+
+func (c *CommandMetadata) clone() *CommandMetadata {
+	result := *c
+	result.Aliases = append([]string{}, c.Aliases...)
+	if c.Deprecation != nil {
+		deprecation := *c.Deprecation
+		result.Deprecation = &deprecation
+	}
+	return &result
+}
+
+var versionedNamePattern = regexp.MustCompile(`^(.+)@[vV](\d+)$`)
+
+// ParseVersionedCommandName splits a command name like "name@v2" into a name and a version.
+//	Parameters: name string a command name with optional version
+//	Returns: the command name and the version, 0 if the version is not specified
+func ParseVersionedCommandName(name string) (string, int) {
+	matches := versionedNamePattern.FindStringSubmatch(name)
+	if matches == nil {
+		return name, 0
+	}
+	version, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return name, 0
+	}
+	return matches[1], version
+}
@@ -2,6 +2,8 @@ package commands
 
 import (
 	"context"
+	"sort"
+	"sync"
 
 	"github.com/pip-services3-gox/pip-services3-commons-gox/data"
 	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
@@ -13,6 +15,10 @@ import (
 // The CommandSet supports command interceptors to extend and the command call chain.
 // CommandSets can be used as alternative commandable interface to a business object.
 // It can be used to auto generate multiple external services for the business object without writing much code.
+// Commands can be registered with versions, aliases and deprecation metadata.
+// They are resolved by names like "name" for the latest version and "name@v2" for a specific version.
+// CommandSet is safe for concurrent registration of commands, events, interceptors and listeners
+// while commands are executed and events are fired.
 //	see Command
 //	see Event
 //	see ICommandable
@@ -38,11 +44,32 @@ import (
 //			)
 //		}
 type CommandSet struct {
-	commands       []ICommand
-	events         []IEvent
-	interceptors   []ICommandInterceptor
-	commandsByName map[string]ICommand
-	eventsByName   map[string]IEvent
+	mtx                sync.RWMutex
+	commands           []ICommand
+	commandsMetadata   []*CommandMetadata
+	events             []IEvent
+	interceptors       []ICommandInterceptor
+	eventInterceptors  []IEventInterceptor
+	commandsByName     map[string]map[int]*commandVersion
+	commandAliases     map[string]*commandAlias
+	eventsByName       map[string]IEvent
+	subscriptions      []*EventSubscription
+	dispatcher         IEventDispatcher
+	journal            *EventJournal
+	deprecationHandler DeprecatedCommandHandler
+}
+
+// commandVersion is a registered version of a command.
+type commandVersion struct {
+	command  ICommand
+	chain    ICommand
+	metadata *CommandMetadata
+}
+
+// commandAlias is an alternative name of a specific command version.
+type commandAlias struct {
+	name    string
+	version int
 }
 
 // NewCommandSet creates an empty CommandSet object.
@@ -52,31 +79,133 @@ func NewCommandSet() *CommandSet {
 		commands:       []ICommand{},
 		events:         []IEvent{},
 		interceptors:   []ICommandInterceptor{},
-		commandsByName: map[string]ICommand{},
+		commandsByName: map[string]map[int]*commandVersion{},
+		commandAliases: map[string]*commandAlias{},
 		eventsByName:   map[string]IEvent{},
 	}
 }
 
 // Commands gets all commands registered in this command set.
+// Each command name is listed once with its latest version, use CommandVersions to get other versions.
 //	see ICommand
+//	see CommandVersions
 //	Returns: []ICommand a list of commands.
 func (c *CommandSet) Commands() []ICommand {
-	return c.commands
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	result := make([]ICommand, 0, len(c.commandsByName))
+	names := map[string]bool{}
+	for _, command := range c.commands {
+		name := command.Name()
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		if latest := latestCommandVersion(c.commandsByName[name]); latest != nil {
+			result = append(result, latest.command)
+		}
+	}
+	return result
+}
+
+// CommandVersions gets versions of a command registered in this command set.
+// Specific versions can be found by names like "name@v2".
+//	see FindCommand
+//	see GetCommandMetadata
+//	Parameters: commandName: string the name of the command or an alias.
+//	Returns: []int registered versions in ascending order or nil if the command is not found.
+func (c *CommandSet) CommandVersions(commandName string) []int {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	versions, ok := c.commandsByName[commandName]
+	if !ok {
+		alias, ok := c.commandAliases[commandName]
+		if !ok {
+			return nil
+		}
+		versions = c.commandsByName[alias.name]
+	}
+
+	result := make([]int, 0, len(versions))
+	for version := range versions {
+		result = append(result, version)
+	}
+	sort.Ints(result)
+	return result
 }
 
 // Events gets all events registered in this command set.
 //	see IEvent
 //	Returns: []IEvent a list of events.
 func (c *CommandSet) Events() []IEvent {
-	return c.events
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	result := make([]IEvent, len(c.events))
+	copy(result, c.events)
+	return result
 }
 
 // FindCommand searches for a command by its name.
+// The name can be a command name, an alias or a name with version like "name@v2".
+// Command names without version resolve the latest version of the command,
+// aliases without version resolve the version that declared them.
 //	see ICommand
 //	Parameters: commandName: string the name of the command to search for.
 //	Returns: ICommand the command, whose name matches the provided name.
 func (c *CommandSet) FindCommand(commandName string) ICommand {
-	return c.commandsByName[commandName]
+	if version := c.resolveCommand(commandName); version != nil {
+		return version.chain
+	}
+	return nil
+}
+
+// GetCommandMetadata gets a version, aliases and deprecation of a command.
+//	see CommandMetadata
+//	Parameters: commandName: string the name of the command, an alias or a name with version like "name@v2".
+//	Returns: *CommandMetadata a copy of the command metadata or nil if the command is not found.
+func (c *CommandSet) GetCommandMetadata(commandName string) *CommandMetadata {
+	if version := c.resolveCommand(commandName); version != nil {
+		return version.metadata.clone()
+	}
+	return nil
+}
+
+func (c *CommandSet) resolveCommand(commandName string) *commandVersion {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	name, version := ParseVersionedCommandName(commandName)
+
+	versions, ok := c.commandsByName[name]
+	if !ok {
+		alias, ok := c.commandAliases[name]
+		if !ok {
+			return nil
+		}
+		versions = c.commandsByName[alias.name]
+		if version == 0 {
+			version = alias.version
+		}
+	}
+
+	if version > 0 {
+		return versions[version]
+	}
+	return latestCommandVersion(versions)
+}
+
+// latestCommandVersion finds the command version with the highest version number.
+func latestCommandVersion(versions map[int]*commandVersion) *commandVersion {
+	var latest *commandVersion
+	for _, v := range versions {
+		if latest == nil || v.metadata.Version > latest.metadata.Version {
+			latest = v
+		}
+	}
+	return latest
 }
 
 // FindEvent searches for an event by its name in this command set.
@@ -84,24 +213,40 @@ func (c *CommandSet) FindCommand(commandName string) ICommand {
 //	Parameters: eventName: string the name of the event to search for.
 //	Returns: IEvent the event, whose name matches the provided name.
 func (c *CommandSet) FindEvent(eventName string) IEvent {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
 	return c.eventsByName[eventName]
 }
 
-func (c *CommandSet) buildCommandChain(command ICommand) {
+func (c *CommandSet) buildCommandChain(command ICommand, metadata *CommandMetadata) {
 	next := command
 
 	for i := len(c.interceptors) - 1; i >= 0; i-- {
 		next = NewInterceptedCommand(c.interceptors[i], next)
 	}
 
-	c.commandsByName[next.Name()] = next
+	name := next.Name()
+	if c.commandsByName[name] == nil {
+		c.commandsByName[name] = map[int]*commandVersion{}
+	}
+	c.commandsByName[name][metadata.Version] = &commandVersion{
+		command:  command,
+		chain:    next,
+		metadata: metadata,
+	}
+
+	for _, alias := range metadata.Aliases {
+		c.commandAliases[alias] = &commandAlias{name: name, version: metadata.Version}
+	}
 }
 
 func (c *CommandSet) rebuildAllCommandChains() {
-	c.commandsByName = map[string]ICommand{}
+	c.commandsByName = map[string]map[int]*commandVersion{}
+	c.commandAliases = map[string]*commandAlias{}
 
-	for _, command := range c.commands {
-		c.buildCommandChain(command)
+	for index, command := range c.commands {
+		c.buildCommandChain(command, c.commandsMetadata[index])
 	}
 }
 
@@ -109,8 +254,42 @@ func (c *CommandSet) rebuildAllCommandChains() {
 //	see ICommand
 //	Parameters: command: ICommand the command to add.
 func (c *CommandSet) AddCommand(command ICommand) {
+	c.AddVersionedCommand(command, nil)
+}
+
+// AddVersionedCommand adds a command with version, aliases and deprecation metadata to this command set.
+// A command with the same name and version replaces the previously added one together with its aliases.
+// Command names take precedence over aliases, so an alias that matches a name of another command is ignored.
+// When several commands declare the same alias, the last added command gets it.
+//	see CommandMetadata
+//	Parameters:
+//		- command: ICommand the command to add.
+//		- metadata: *CommandMetadata the command metadata or nil for version 1.
+func (c *CommandSet) AddVersionedCommand(command ICommand, metadata *CommandMetadata) {
+	if metadata == nil {
+		metadata = NewCommandMetadata(1)
+	} else {
+		metadata = metadata.clone()
+		if metadata.Version < 1 {
+			metadata.Version = 1
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for index, registered := range c.commands {
+		if registered.Name() == command.Name() && c.commandsMetadata[index].Version == metadata.Version {
+			c.commands[index] = command
+			c.commandsMetadata[index] = metadata
+			c.rebuildAllCommandChains()
+			return
+		}
+	}
+
 	c.commands = append(c.commands, command)
-	c.buildCommandChain(command)
+	c.commandsMetadata = append(c.commandsMetadata, metadata)
+	c.buildCommandChain(command, metadata)
 }
 
 // AddCommands adds multiple commands to this command set.
@@ -126,8 +305,17 @@ func (c *CommandSet) AddCommands(commands []ICommand) {
 //	see IEvent
 //	Parameters: IEvent the event to add.
 func (c *CommandSet) AddEvent(event IEvent) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
 	c.events = append(c.events, event)
 	c.eventsByName[event.Name()] = event
+
+	for _, subscription := range c.subscriptions {
+		if subscription.Matches(event.Name()) {
+			event.AddListener(subscription)
+		}
+	}
 }
 
 // AddEvents adds multiple events to this command set.
@@ -142,7 +330,14 @@ func (c *CommandSet) AddEvents(events []IEvent) {
 // AddCommandSet adds all the commands and events from specified command set into this one.
 //	Parameters: commandSet: *CommandSet the CommandSet to add.
 func (c *CommandSet) AddCommandSet(commandSet *CommandSet) {
-	c.AddCommands(commandSet.Commands())
+	commandSet.mtx.RLock()
+	commands := append([]ICommand{}, commandSet.commands...)
+	metadata := append([]*CommandMetadata{}, commandSet.commandsMetadata...)
+	commandSet.mtx.RUnlock()
+
+	for index, command := range commands {
+		c.AddVersionedCommand(command, metadata[index])
+	}
 	c.AddEvents(commandSet.Events())
 }
 
@@ -150,7 +345,7 @@ func (c *CommandSet) AddCommandSet(commandSet *CommandSet) {
 //	see IEventListener
 //	Parameters: listener: IEventListener the listener to add.
 func (c *CommandSet) AddListener(listener IEventListener) {
-	for _, event := range c.events {
+	for _, event := range c.Events() {
 		event.AddListener(listener)
 	}
 }
@@ -159,20 +354,164 @@ func (c *CommandSet) AddListener(listener IEventListener) {
 //	see IEventListener
 //	Parameters: IEventListener the listener to remove.
 func (c *CommandSet) RemoveListener(listener IEventListener) {
-	for _, event := range c.events {
+	for _, event := range c.Events() {
 		event.RemoveListener(listener)
 	}
 }
 
+// Subscribe subscribes a listener to events selected by names and arguments.
+// The subscription covers events that are added to this command set later.
+//	see GlobEventMatcher
+//	see RegexEventMatcher
+//	Example:
+//		subscription := commandSet.Subscribe(GlobEventMatcher("order.*"),
+//			func(args *run.Parameters) bool {
+//				return args.GetAsString("status") == "paid"
+//			},
+//			listener)
+//		...
+//		subscription.Unsubscribe()
+//	Parameters:
+//		- matcher: EventNameMatcher (optional) a matcher of event names, nil to match all events.
+//		- predicate: EventPredicate (optional) a predicate over event arguments, nil to accept all arguments.
+//		- listener: IEventListener the listener to subscribe.
+//	Returns: *EventSubscription a handle to unsubscribe the listener.
+func (c *CommandSet) Subscribe(matcher EventNameMatcher, predicate EventPredicate,
+	listener IEventListener) *EventSubscription {
+
+	if listener == nil {
+		panic("Listener cannot be nil")
+	}
+
+	subscription := &EventSubscription{
+		commandSet: c,
+		matcher:    matcher,
+		predicate:  predicate,
+		listener:   listener,
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.subscriptions = append(c.subscriptions, subscription)
+	for _, event := range c.events {
+		if subscription.Matches(event.Name()) {
+			event.AddListener(subscription)
+		}
+	}
+	return subscription
+}
+
+// SubscribeGlob subscribes a listener to events with names that match a glob pattern like "order.*".
+//	see Subscribe
+//	Parameters:
+//		- pattern: string a glob pattern of event names.
+//		- predicate: EventPredicate (optional) a predicate over event arguments, nil to accept all arguments.
+//		- listener: IEventListener the listener to subscribe.
+//	Returns: *EventSubscription a handle to unsubscribe the listener.
+func (c *CommandSet) SubscribeGlob(pattern string, predicate EventPredicate,
+	listener IEventListener) *EventSubscription {
+
+	return c.Subscribe(GlobEventMatcher(pattern), predicate, listener)
+}
+
+// SubscribeRegex subscribes a listener to events with names that match a regular expression.
+//	see Subscribe
+//	Parameters:
+//		- pattern: string a regular expression of event names.
+//		- predicate: EventPredicate (optional) a predicate over event arguments, nil to accept all arguments.
+//		- listener: IEventListener the listener to subscribe.
+//	Returns: (*EventSubscription, error) a handle to unsubscribe the listener or error if the expression is invalid.
+func (c *CommandSet) SubscribeRegex(pattern string, predicate EventPredicate,
+	listener IEventListener) (*EventSubscription, error) {
+
+	matcher, err := RegexEventMatcher(pattern)
+	if err != nil {
+		return nil, errors.NewBadRequestError("", "INVALID_PATTERN", "Invalid event name pattern "+pattern).
+			WithDetails("pattern", pattern).
+			WithCause(err)
+	}
+	return c.Subscribe(matcher, predicate, listener), nil
+}
+
+func (c *CommandSet) unsubscribe(subscription *EventSubscription) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for index, s := range c.subscriptions {
+		if s == subscription {
+			c.subscriptions = append(c.subscriptions[:index:index], c.subscriptions[index+1:]...)
+			break
+		}
+	}
+
+	for _, event := range c.events {
+		event.RemoveListener(subscription)
+	}
+}
+
+// SetEventDispatcher sets a dispatcher that delivers events fired through Notify of this command set.
+//	see EventDispatcher
+//	Parameters: dispatcher: IEventDispatcher the dispatcher or nil to notify events directly.
+func (c *CommandSet) SetEventDispatcher(dispatcher IEventDispatcher) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.dispatcher = dispatcher
+}
+
+// SetEventJournal sets a journal that records events fired through Notify of this command set.
+//	see EventJournal
+//	Parameters: journal: *EventJournal the journal or nil to stop recording events.
+func (c *CommandSet) SetEventJournal(journal *EventJournal) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.journal = journal
+}
+
+// SetDeprecationHandler sets a callback that is called when a deprecated command is executed.
+//	see CommandMetadata
+//	Parameters: handler: DeprecatedCommandHandler the callback or nil to remove it.
+func (c *CommandSet) SetDeprecationHandler(handler DeprecatedCommandHandler) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.deprecationHandler = handler
+}
+
 // AddInterceptor adds a command interceptor to this command set.
 //	see ICommandInterceptor
 //	Parameters: ICommandInterceptor the interceptor to add.
 func (c *CommandSet) AddInterceptor(interceptor ICommandInterceptor) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
 	c.interceptors = append(c.interceptors, interceptor)
 	c.rebuildAllCommandChains()
 }
 
+// AddEventInterceptor adds an event interceptor to this command set.
+// Interceptors are called in the order they were added, before events are recorded
+// in the event journal and passed to listeners. Replayed events are not intercepted.
+//	see IEventInterceptor
+//	Parameters: IEventInterceptor the interceptor to add.
+func (c *CommandSet) AddEventInterceptor(interceptor IEventInterceptor) {
+	if interceptor == nil {
+		panic("Interceptor cannot be nil")
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.eventInterceptors = append(c.eventInterceptors, interceptor)
+}
+
 // Execute a command specified by its name.
+// Interceptors that implement ICommandAuthorizer are called before arguments are validated.
+// A missing correlation id is taken from the context, or generated if the context has none.
+// The correlation id is put into the context passed to the command.
+//	see run.WithCorrelationId
 //	see ICommand
 //	see Parameters
 //	Parameters:
@@ -184,9 +523,10 @@ func (c *CommandSet) AddInterceptor(interceptor ICommandInterceptor) {
 //		- result: any
 //		- err: error
 func (c *CommandSet) Execute(ctx context.Context, correlationId string, commandName string, args *run.Parameters) (result any, err error) {
-	cref := c.FindCommand(commandName)
+	ctx, correlationId = syncCorrelationId(ctx, correlationId)
+	version := c.resolveCommand(commandName)
 
-	if cref == nil {
+	if version == nil {
 		err := errors.NewBadRequestError(
 			correlationId,
 			"CMD_NOT_FOUND",
@@ -197,18 +537,50 @@ func (c *CommandSet) Execute(ctx context.Context, correlationId string, commandN
 
 	if correlationId == "" {
 		correlationId = data.IdGenerator.NextShort()
+		ctx = run.WithCorrelationId(ctx, correlationId)
+	}
+
+	if version.metadata.IsDeprecated() {
+		c.mtx.RLock()
+		handler := c.deprecationHandler
+		c.mtx.RUnlock()
+
+		if handler != nil {
+			handler(ctx, correlationId, commandName, version.metadata.clone())
+		}
+	}
+
+	if err := c.authorize(ctx, correlationId, version.command.Name()); err != nil {
+		return nil, err
 	}
+	ctx = withAuthorizedCommand(ctx, version.command.Name())
 
 	// Validate parameters
+	cref := version.chain
 	results := cref.Validate(args)
-	if len(results) > 0 {
-		err := validate.NewValidationErrorFromResults(correlationId, results, false)
+	if err := validate.NewValidationErrorFromResults(correlationId, results, false); err != nil {
 		return nil, err
 	}
 
 	return cref.Execute(ctx, correlationId, args)
 }
 
+// authorize calls interceptors that implement ICommandAuthorizer.
+func (c *CommandSet) authorize(ctx context.Context, correlationId string, command string) error {
+	c.mtx.RLock()
+	interceptors := c.interceptors
+	c.mtx.RUnlock()
+
+	for _, interceptor := range interceptors {
+		if authorizer, ok := interceptor.(ICommandAuthorizer); ok {
+			if err := authorizer.Authorize(ctx, correlationId, command); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Validate args for command specified by its name using defined schema. If validation schema is
 // not defined than the methods returns no errors. It returns validation error if the command is not found.
 //	see Command
@@ -240,14 +612,244 @@ func (c *CommandSet) Validate(commandName string, args *run.Parameters) []*valid
 	return cref.Validate(args)
 }
 
-// Notify fires event specified by its name and notifies all registered listeners
+// ExecuteBatch executes a batch of commands. All commands are authorized and validated before execution,
+// and if any of them is not found, not allowed or has invalid arguments, none is executed.
+// Arguments of commands that are not allowed are not validated.
+// Commands are executed according to the mode and results are returned for each command in the batch.
+// A missing correlation id is taken from the context or generated, so all commands in the batch share one id.
+//	see BatchMode
+//	see ICompensable
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- batch: []*BatchCommand commands to execute.
+//		- mode: BatchMode the execution mode.
+//	Returns:
+//		- results: []*BatchResult results in the order of commands in the batch
+//		- err: error an aggregated error with failures of all commands, nil if all commands succeeded
+func (c *CommandSet) ExecuteBatch(ctx context.Context, correlationId string, batch []*BatchCommand,
+	mode BatchMode) (results []*BatchResult, err error) {
+
+	ctx, correlationId = syncCorrelationId(ctx, correlationId)
+	if correlationId == "" {
+		correlationId = data.IdGenerator.NextShort()
+		ctx = run.WithCorrelationId(ctx, correlationId)
+	}
+
+	// Validate all commands before execution
+	results = make([]*BatchResult, len(batch))
+	failure := errors.NewAggregateError(correlationId, "BATCH_INVALID", "Batch validation failed")
+	for index, item := range batch {
+		results[index] = &BatchResult{Command: item.Command}
+
+		var itemErr error
+		version := c.resolveCommand(item.Command)
+		if version == nil {
+			itemErr = errors.NewBadRequestError(
+				correlationId,
+				"CMD_NOT_FOUND",
+				"Request command does not exist",
+			).WithDetails("command", item.Command)
+		} else if authErr := c.authorize(ctx, correlationId, version.command.Name()); authErr != nil {
+			itemErr = authErr
+		} else if validationErr := validate.NewValidationErrorFromResults(
+			correlationId, c.Validate(item.Command, item.Args), false); validationErr != nil {
+			itemErr = validationErr
+		}
+
+		if itemErr != nil {
+			results[index].Err = itemErr
+			failure.AddComponentError(item.Command, itemErr)
+		}
+	}
+	if len(failure.Errors()) > 0 {
+		return results, failure
+	}
+
+	switch mode {
+	case BatchParallel:
+		var wg sync.WaitGroup
+		for index, item := range batch {
+			wg.Add(1)
+			go func(result *BatchResult, item *BatchCommand) {
+				defer wg.Done()
+				result.Result, result.Err = c.Execute(ctx, correlationId, item.Command, item.Args)
+				result.Executed = true
+			}(results[index], item)
+		}
+		wg.Wait()
+	case BatchAllOrNothing:
+		return results, c.executeAllOrNothing(ctx, correlationId, batch, results)
+	default:
+		for index, item := range batch {
+			results[index].Result, results[index].Err = c.Execute(ctx, correlationId, item.Command, item.Args)
+			results[index].Executed = true
+		}
+	}
+
+	failure = errors.NewAggregateError(correlationId, "BATCH_FAILED", "Batch execution failed")
+	for _, result := range results {
+		if result.Err != nil {
+			failure.AddComponentError(result.Command, result.Err)
+		}
+	}
+	if len(failure.Errors()) > 0 {
+		return results, failure
+	}
+	return results, nil
+}
+
+func (c *CommandSet) executeAllOrNothing(ctx context.Context, correlationId string, batch []*BatchCommand,
+	results []*BatchResult) error {
+
+	failedIndex := -1
+	for index, item := range batch {
+		results[index].Result, results[index].Err = c.Execute(ctx, correlationId, item.Command, item.Args)
+		results[index].Executed = true
+		if results[index].Err != nil {
+			failedIndex = index
+			break
+		}
+	}
+	if failedIndex < 0 {
+		return nil
+	}
+
+	failure := errors.NewAggregateError(correlationId, "BATCH_FAILED", "Batch execution failed and was rolled back")
+	failure.AddComponentError(batch[failedIndex].Command, results[failedIndex].Err)
+
+	for index := failedIndex + 1; index < len(batch); index++ {
+		results[index].Err = errors.NewInvocationError(
+			correlationId,
+			"BATCH_ABORTED",
+			"Command "+batch[index].Command+" was not executed because batch was aborted",
+		).WithDetails("command", batch[index].Command)
+	}
+
+	// Roll back executed commands in reverse order
+	for index := failedIndex - 1; index >= 0; index-- {
+		version := c.resolveCommand(batch[index].Command)
+		if version == nil {
+			continue
+		}
+		compensable, ok := version.command.(ICompensable)
+		if !ok {
+			continue
+		}
+
+		err := compensable.Compensate(ctx, correlationId, batch[index].Args, results[index].Result)
+		if err != nil {
+			failure.AddComponentError(batch[index].Command, errors.NewInvocationError(
+				correlationId,
+				"COMPENSATION_FAILED",
+				"Compensation of command "+batch[index].Command+" failed",
+			).WithDetails("command", batch[index].Command).WithCause(err))
+			continue
+		}
+		results[index].Compensated = true
+	}
+
+	return failure
+}
+
+// Notify fires event specified by its name and notifies all registered listeners.
+// If an event journal is set, the event is recorded before listeners are notified.
+// If an event dispatcher is set, the listeners are notified through it.
+// Event interceptors are called before the event is recorded and listeners are notified.
+// A missing correlation id is taken from the context, and the id is put into the context passed to listeners.
 //	Parameters:
 //		- ctx context.Context.
 //		- correlationId: string (optional) transaction id to trace execution through call chain.
 //		- eventName: string the name of the event that is to be fired.
 //		- args: Parameters the event arguments (parameters).
 func (c *CommandSet) Notify(ctx context.Context, correlationId string, eventName string, args *run.Parameters) {
-	if event := c.FindEvent(eventName); event != nil {
+	ctx, correlationId = syncCorrelationId(ctx, correlationId)
+
+	c.mtx.RLock()
+	event := c.eventsByName[eventName]
+	interceptors := c.eventInterceptors
+	c.mtx.RUnlock()
+
+	if event == nil {
+		return
+	}
+
+	var chain IEvent = &commandSetEvent{IEvent: event, commandSet: c}
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		chain = NewInterceptedEvent(interceptors[i], chain)
+	}
+	chain.Notify(ctx, correlationId, args)
+}
+
+// commandSetEvent is the last link in a notification chain,
+// that records the event in the journal and notifies listeners.
+type commandSetEvent struct {
+	IEvent
+	commandSet *CommandSet
+}
+
+func (c *commandSetEvent) Notify(ctx context.Context, correlationId string, args *run.Parameters) {
+	c.commandSet.mtx.RLock()
+	journal := c.commandSet.journal
+	c.commandSet.mtx.RUnlock()
+
+	if journal != nil {
+		// Recording errors are reported to the journal error handler
+		_ = journal.Record(ctx, correlationId, c.Name(), args)
+	}
+
+	c.commandSet.notifyListeners(ctx, correlationId, c.IEvent, args)
+}
+
+// syncCorrelationId fills a missing correlation id from the context and puts the id into the context,
+// so commands, interceptors and listeners get the same id from both.
+func syncCorrelationId(ctx context.Context, correlationId string) (context.Context, string) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if correlationId == "" {
+		return ctx, run.GetCorrelationId(ctx)
+	}
+	if run.GetCorrelationId(ctx) != correlationId {
+		ctx = run.WithCorrelationId(ctx, correlationId)
+	}
+	return ctx, correlationId
+}
+
+func (c *CommandSet) notifyListeners(ctx context.Context, correlationId string, event IEvent, args *run.Parameters) {
+	c.mtx.RLock()
+	dispatcher := c.dispatcher
+	c.mtx.RUnlock()
+
+	if dispatcher != nil {
+		dispatcher.Dispatch(ctx, correlationId, event, event.Listeners(), args)
+	} else {
 		event.Notify(ctx, correlationId, args)
 	}
 }
+
+// ReplayEvents notifies listeners of this command set about events recorded in the event journal.
+// Replayed events are not recorded again. Records of events that are not registered are skipped.
+//	see EventJournal
+//	Parameters:
+//		- ctx context.Context.
+//		- filter: *EventFilter (optional) criteria to select recorded events.
+//	Returns: (int, error) a number of replayed events
+func (c *CommandSet) ReplayEvents(ctx context.Context, filter *EventFilter) (int, error) {
+	c.mtx.RLock()
+	journal := c.journal
+	c.mtx.RUnlock()
+
+	if journal == nil {
+		return 0, errors.NewInvalidStateError("", "NO_EVENT_JOURNAL", "Event journal is not set")
+	}
+
+	count := 0
+	_, err := journal.replay(ctx, filter, func(ctx context.Context, record *EventRecord, args *run.Parameters) {
+		if event := c.FindEvent(record.Event); event != nil {
+			c.notifyListeners(ctx, record.CorrelationId, event, args)
+			count++
+		}
+	})
+	return count, err
+}
@@ -0,0 +1,353 @@
+package commands
+
+import (
+	"encoding/json"
+	refl "reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+// CommandSetExporter exports contracts of commands and events in a CommandSet
+// as JSON Schema, OpenAPI 3 and Markdown documents.
+//
+// Each command is exported as a POST operation with the command name as a path and
+// JSON Schema of its arguments as a request body. Errors are described by ErrorDescription.
+// Command schemas are read from commands that implement GetSchema, like Command and TypedCommand.
+// The latest version of a command is exported by its name, other versions by names like "name@v1".
+// Aliases are listed in "x-aliases" extension of operations and in Markdown references.
+// Deprecated commands are marked as deprecated.
+//
+// Argument schemas are exported for non-strict validation used by CommandSet, so undefined properties are allowed.
+// Notice that JSON Schema property names are case-sensitive, while commands match argument names case-insensitively.
+//	see validate.JsonSchemaConverter
+//	Example:
+//		exporter := NewCommandSetExporter(commandSet).
+//			WithTitle("Orders API").
+//			WithVersion("1.0.0").
+//			WithBasePath("/v1/orders")
+//
+//		openApi, err := exporter.ToOpenApiJson()
+//		markdown := exporter.ToMarkdown()
+type CommandSetExporter struct {
+	commandSet *CommandSet
+	title      string
+	version    string
+	basePath   string
+}
+
+// NewCommandSetExporter creates a new exporter for the command set.
+//	Parameters: commandSet *CommandSet a command set to export
+//	Returns: *CommandSetExporter
+func NewCommandSetExporter(commandSet *CommandSet) *CommandSetExporter {
+	if commandSet == nil {
+		panic("Command set cannot be nil")
+	}
+
+	return &CommandSetExporter{
+		commandSet: commandSet,
+		title:      "Commands",
+		version:    "1.0.0",
+	}
+}
+
+// WithTitle sets a title of exported documents.
+//	Parameters: title string a document title
+//	Returns: *CommandSetExporter
+func (c *CommandSetExporter) WithTitle(title string) *CommandSetExporter {
+	c.title = title
+	return c
+}
+
+// WithVersion sets an API version of exported documents.
+//	Parameters: version string an API version
+//	Returns: *CommandSetExporter
+func (c *CommandSetExporter) WithVersion(version string) *CommandSetExporter {
+	c.version = version
+	return c
+}
+
+// WithBasePath sets a path prefix for command operations.
+//	Parameters: basePath string a path prefix, for instance "/v1/orders"
+//	Returns: *CommandSetExporter
+func (c *CommandSetExporter) WithBasePath(basePath string) *CommandSetExporter {
+	c.basePath = strings.TrimRight(basePath, "/")
+	return c
+}
+
+// commandSchema gets the validation schema of the command or nil if it is not defined.
+func commandSchema(command ICommand) validate.ISchema {
+	if s, ok := command.(interface{ GetSchema() validate.ISchema }); ok {
+		return s.GetSchema()
+	}
+	return nil
+}
+
+// exportedCommand is a command version with the name it is exported by.
+type exportedCommand struct {
+	name     string
+	command  ICommand
+	metadata *CommandMetadata
+}
+
+func (c *CommandSetExporter) sortedCommands() []*exportedCommand {
+	c.commandSet.mtx.RLock()
+	commands := []*exportedCommand{}
+	for name, versions := range c.commandSet.commandsByName {
+		latest := 0
+		for version := range versions {
+			if version > latest {
+				latest = version
+			}
+		}
+		for version, v := range versions {
+			exportedName := name
+			if version != latest {
+				exportedName = name + "@v" + strconv.Itoa(version)
+			}
+			commands = append(commands, &exportedCommand{
+				name:     exportedName,
+				command:  v.command,
+				metadata: v.metadata,
+			})
+		}
+	}
+	c.commandSet.mtx.RUnlock()
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].name < commands[j].name
+	})
+	return commands
+}
+
+func (c *CommandSetExporter) sortedEventNames() []string {
+	events := c.commandSet.Events()
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, event.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ToJsonSchemas converts argument schemas of all commands into JSON Schemas.
+// Commands without schemas accept any object.
+//	Returns: map[string]any JSON Schemas by command names
+func (c *CommandSetExporter) ToJsonSchemas() map[string]any {
+	result := map[string]any{}
+	for _, command := range c.sortedCommands() {
+		result[command.name] = c.argsJsonSchema(command.command)
+	}
+	return result
+}
+
+func (c *CommandSetExporter) argsJsonSchema(command ICommand) map[string]any {
+	if schema := commandSchema(command); schema != nil {
+		return validate.JsonSchemaConverter.ToJsonSchema(schema)
+	}
+	return map[string]any{"type": "object"}
+}
+
+// ToOpenApi creates OpenAPI 3 document with one POST operation per command.
+// Events are listed in "x-events" extension.
+//	Returns: map[string]any OpenAPI document
+func (c *CommandSetExporter) ToOpenApi() map[string]any {
+	paths := map[string]any{}
+	for _, command := range c.sortedCommands() {
+		operation := map[string]any{
+			"operationId": command.name,
+			"requestBody": map[string]any{
+				"required": true,
+				"content": map[string]any{
+					"application/json": map[string]any{
+						"schema": c.argsJsonSchema(command.command),
+					},
+				},
+			},
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "Command result",
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": map[string]any{},
+						},
+					},
+				},
+				"default": map[string]any{
+					"description": "Command error",
+					"content": map[string]any{
+						"application/json": map[string]any{
+							"schema": map[string]any{"$ref": "#/components/schemas/ErrorDescription"},
+						},
+					},
+				},
+			},
+		}
+		if len(command.metadata.Aliases) > 0 {
+			operation["x-aliases"] = command.metadata.Aliases
+		}
+		if command.metadata.IsDeprecated() {
+			operation["deprecated"] = true
+			operation["description"] = describeDeprecation(command.metadata.Deprecation)
+		}
+
+		paths[c.basePath+"/"+command.name] = map[string]any{
+			"post": operation,
+		}
+	}
+
+	result := map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":   c.title,
+			"version": c.version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": map[string]any{
+				"ErrorDescription": validate.JsonSchemaConverter.ToJsonSchema(refl.TypeOf(errors.ErrorDescription{})),
+			},
+		},
+	}
+
+	if events := c.sortedEventNames(); len(events) > 0 {
+		result["x-events"] = events
+	}
+	return result
+}
+
+// ToOpenApiJson creates OpenAPI 3 document serialized into JSON.
+//	Returns: (string, error) OpenAPI document in JSON or error if serialization failed
+func (c *CommandSetExporter) ToOpenApiJson() (string, error) {
+	buffer, err := json.MarshalIndent(c.ToOpenApi(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(buffer), nil
+}
+
+// ToMarkdown creates a Markdown reference of commands and events.
+// Object arguments are described by tables, other arguments by JSON Schemas.
+//	Returns: string Markdown document
+func (c *CommandSetExporter) ToMarkdown() string {
+	builder := strings.Builder{}
+	builder.WriteString("# " + c.title + "\n\n")
+	builder.WriteString("Version: " + c.version + "\n")
+
+	commands := c.sortedCommands()
+	if len(commands) > 0 {
+		builder.WriteString("\n## Commands\n")
+	}
+	for _, command := range commands {
+		builder.WriteString("\n### " + command.name + "\n\n")
+		builder.WriteString("`POST " + c.basePath + "/" + command.name + "`\n\n")
+		if len(command.metadata.Aliases) > 0 {
+			builder.WriteString("Aliases: `" + strings.Join(command.metadata.Aliases, "`, `") + "`\n\n")
+		}
+		if command.metadata.IsDeprecated() {
+			builder.WriteString("**" + describeDeprecation(command.metadata.Deprecation) + "**\n\n")
+		}
+		writeMarkdownArgs(&builder, c.argsJsonSchema(command.command))
+	}
+
+	events := c.sortedEventNames()
+	if len(events) > 0 {
+		builder.WriteString("\n## Events\n\n")
+	}
+	for _, name := range events {
+		builder.WriteString("- " + name + "\n")
+	}
+
+	return builder.String()
+}
+
+func describeDeprecation(deprecation *CommandDeprecation) string {
+	result := "Deprecated"
+	if deprecation.Since != "" {
+		result += " since " + deprecation.Since
+	}
+	result += "."
+	if deprecation.Replacement != "" {
+		result += " Use " + deprecation.Replacement + " instead."
+	}
+	if !deprecation.Sunset.IsZero() {
+		result += " Will be removed on " + deprecation.Sunset.Format("2006-01-02") + "."
+	}
+	return result
+}
+
+func writeMarkdownArgs(builder *strings.Builder, schema map[string]any) {
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok || len(properties) == 0 {
+		if len(schema) <= 1 {
+			builder.WriteString("No arguments defined.\n")
+			return
+		}
+		buffer, _ := json.MarshalIndent(schema, "", "  ")
+		builder.WriteString("```json\n" + string(buffer) + "\n```\n")
+		return
+	}
+
+	required := map[string]bool{}
+	if names, ok := schema["required"].([]string); ok {
+		for _, name := range names {
+			required[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	builder.WriteString("| Name | Type | Required | Constraints |\n")
+	builder.WriteString("|------|------|----------|-------------|\n")
+	for _, name := range names {
+		property, _ := properties[name].(map[string]any)
+		typ, constraints := describeJsonSchema(property)
+		requiredText := "no"
+		if required[name] {
+			requiredText = "yes"
+		}
+		builder.WriteString("| " + name + " | " + typ + " | " + requiredText + " | " + constraints + " |\n")
+	}
+}
+
+// describeJsonSchema gets a short type name and a description of other keywords of JSON Schema.
+func describeJsonSchema(schema map[string]any) (string, string) {
+	typ, _ := schema["type"].(string)
+	if typ == "" {
+		typ = "any"
+	}
+	if format, ok := schema["format"].(string); ok {
+		typ += " (" + format + ")"
+	}
+	if typ == "array" {
+		if items, ok := schema["items"].(map[string]any); ok {
+			itemType, _ := describeJsonSchema(items)
+			typ = "array of " + itemType
+		}
+	}
+
+	keys := make([]string, 0, len(schema))
+	for key := range schema {
+		switch key {
+		case "type", "format", "items", "properties", "required", "additionalProperties":
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	constraints := make([]string, 0, len(keys))
+	for _, key := range keys {
+		buffer, _ := json.Marshal(schema[key])
+		// Pipes break Markdown tables
+		constraints = append(constraints, "`"+key+": "+strings.ReplaceAll(string(buffer), "|", "\\|")+"`")
+	}
+	return typ, strings.Join(constraints, ", ")
+}
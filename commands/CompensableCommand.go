@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+// CompensableCommand is a command that can undo its execution.
+//	see ICompensable
+//	Example:
+//		command := NewCompensableCommand("create_item", nil,
+//			func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+//				return controller.CreateItem(ctx, correlationId, args.GetAsString("name"))
+//			},
+//			func(ctx context.Context, correlationId string, args *run.Parameters, result any) error {
+//				return controller.DeleteItem(ctx, correlationId, result.(*Item).Id)
+//			})
+type CompensableCommand struct {
+	*Command
+	compensation func(ctx context.Context, correlationId string, args *run.Parameters, result any) error
+}
+
+// NewCompensableCommand creates a new compensable command.
+//	Parameters:
+//		- name: string - the command name.
+//		- schema: validate.ISchema the schema to validate command arguments.
+//		- action: func(ctx context.Context, correlationId string, args *run.Parameters) (any, error)
+//			the function to be executed by this command.
+//		- compensation: func(ctx context.Context, correlationId string, args *run.Parameters, result any) error
+//			the function that undoes the execution.
+//	Returns: *CompensableCommand
+func NewCompensableCommand(name string, schema validate.ISchema,
+	action func(ctx context.Context, correlationId string, args *run.Parameters) (any, error),
+	compensation func(ctx context.Context, correlationId string, args *run.Parameters, result any) error) *CompensableCommand {
+
+	if compensation == nil {
+		panic("Compensation cannot be nil")
+	}
+
+	return &CompensableCommand{
+		Command:      NewCommand(name, schema, action),
+		compensation: compensation,
+	}
+}
+
+// Compensate undoes a successful execution of the command.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- args: *run.Parameters the parameters (arguments) the command was executed with.
+//		- result: any the result of the execution.
+//	Returns: error
+func (c *CompensableCommand) Compensate(ctx context.Context, correlationId string, args *run.Parameters, result any) error {
+	return c.compensation(ctx, correlationId, args, result)
+}
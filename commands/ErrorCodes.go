@@ -0,0 +1,34 @@
+package commands
+
+import "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+
+// Registers error codes raised by commands in the error catalog.
+func init() {
+	errors.ErrorCatalog.Register("CMD_NOT_FOUND", errors.BadRequest, 400, "Requested command {{command}} does not exist")
+	errors.ErrorCatalog.Register("EXEC_FAILED", errors.FailedInvocation, 500, "Execution {{command}} failed")
+	errors.ErrorCatalog.Register("INVALID_ARGS", errors.BadRequest, 400, "Invalid command arguments")
+	errors.ErrorCatalog.Register("INVALID_FORMAT", errors.BadRequest, 400, "Unsupported output format {{format}}")
+	errors.ErrorCatalog.Register("OUTPUT_FAILED", errors.Internal, 500, "Failed to print command result")
+	errors.ErrorCatalog.Register("METHOD_NOT_ALLOWED", errors.Unsupported, 405, "Method {{method}} is not allowed")
+	errors.ErrorCatalog.Register("INVALID_BODY", errors.BadRequest, 400, "Request body is not a JSON object")
+	errors.ErrorCatalog.Register("BODY_TOO_LARGE", errors.BadRequest, 413, "Request body exceeds {{max_size}} bytes")
+	errors.ErrorCatalog.Register("INVALID_REQUEST", errors.BadRequest, 400, "Invalid JSON-RPC request")
+	errors.ErrorCatalog.Register("CONNECT_FAILED", errors.NoResponse, 503, "Failed to call remote command {{command}}")
+	errors.ErrorCatalog.Register("RESPONSE_READ_FAILED", errors.NoResponse, 503, "Failed to read response of remote command {{command}}")
+	errors.ErrorCatalog.Register("INVALID_RESPONSE", errors.FailedInvocation, 500, "Remote command {{command}} returned invalid JSON")
+	errors.ErrorCatalog.Register("NOT_AUTHENTICATED", errors.Unauthorized, 401, "Command {{command}} requires authenticated caller")
+	errors.ErrorCatalog.Register("COMMAND_ACCESS_DENIED", errors.Forbidden, 403, "Command {{command}} is not allowed")
+	errors.ErrorCatalog.Register("IDEMPOTENCY_KEY_REUSED", errors.Conflict, 409, "Idempotency key {{key}} was used with different arguments")
+	errors.ErrorCatalog.Register("RATE_LIMIT_EXCEEDED", errors.NoResponse, 429, "Rate limit for command {{command}} exceeded")
+	errors.ErrorCatalog.Register("CIRCUIT_OPEN", errors.NoResponse, 503, "Circuit for command {{command}} is open")
+	errors.ErrorCatalog.Register("BATCH_INVALID", errors.BadRequest, 400, "Batch validation failed")
+	errors.ErrorCatalog.Register("BATCH_FAILED", errors.FailedInvocation, 500, "Batch execution failed")
+	errors.ErrorCatalog.Register("BATCH_ABORTED", errors.FailedInvocation, 500, "Command {{command}} was not executed because batch was aborted")
+	errors.ErrorCatalog.Register("COMPENSATION_FAILED", errors.FailedInvocation, 500, "Compensation of command {{command}} failed")
+	errors.ErrorCatalog.Register("EVENT_FAILED", errors.FailedInvocation, 500, "Notification of event {{event}} failed")
+	errors.ErrorCatalog.Register("INVALID_PATTERN", errors.BadRequest, 400, "Invalid event name pattern {{pattern}}")
+	errors.ErrorCatalog.Register("JOURNAL_WRITE_FAILED", errors.FileError, 500, "Failed to write event journal {{path}}")
+	errors.ErrorCatalog.Register("JOURNAL_READ_FAILED", errors.FileError, 500, "Failed to read event journal {{path}}")
+	errors.ErrorCatalog.Register("NO_EVENT_JOURNAL", errors.InvalidState, 500, "Event journal is not set")
+	errors.ErrorCatalog.Register("DISPATCHER_CLOSED", errors.InvalidState, 500, "Event dispatcher is closed")
+}
@@ -2,11 +2,16 @@ package commands
 
 import (
 	"context"
+	"sync"
+
 	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
 )
 
 // Event concrete implementation of IEvent interface. It allows to send asynchronous
 // notifications to multiple subscribed listeners.
+// Listeners can be safely added and removed while the event is fired.
+// Each notification is delivered to a snapshot of listeners taken when it starts.
+// By default listeners are notified synchronously, this can be changed by setting an IEventDispatcher.
 //	Example:
 //		event: = NewEvent("my_event");
 //		event.AddListener(myListener);
@@ -16,8 +21,10 @@ import (
 //			"param2", 123,
 //		));
 type Event struct {
-	name      string
-	listeners []IEventListener
+	mtx        sync.RWMutex
+	name       string
+	listeners  []IEventListener
+	dispatcher IEventDispatcher
 }
 
 // NewEvent creates a new event and assigns its name.
@@ -44,21 +51,45 @@ func (c *Event) Name() string {
 // Listeners gets all listeners registered in this event.
 //	Returns: []IEventListener a list of listeners.
 func (c *Event) Listeners() []IEventListener {
-	return c.listeners
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	result := make([]IEventListener, len(c.listeners))
+	copy(result, c.listeners)
+	return result
+}
+
+// SetDispatcher sets a dispatcher that delivers notifications to listeners.
+//	see EventDispatcher
+//	Parameters: dispatcher: IEventDispatcher the dispatcher or nil to notify listeners synchronously.
+func (c *Event) SetDispatcher(dispatcher IEventDispatcher) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.dispatcher = dispatcher
 }
 
 // AddListener adds a listener to receive notifications when this event is fired.
 //	Parameters: listener: IEventListener the listener reference to add.
 func (c *Event) AddListener(listener IEventListener) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
 	c.listeners = append(c.listeners, listener)
 }
 
 // RemoveListener removes a listener, so that it no longer receives notifications for this event.
 //	Parameters: listener: IEventListener the listener reference to remove.
 func (c *Event) RemoveListener(listener IEventListener) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
 	for i, l := range c.listeners {
 		if listener == l {
-			c.listeners = append(c.listeners[:i], c.listeners[i+1:]...)
+			// Copy on write to keep snapshots taken by Notify intact
+			listeners := make([]IEventListener, 0, len(c.listeners)-1)
+			listeners = append(listeners, c.listeners[:i]...)
+			c.listeners = append(listeners, c.listeners[i+1:]...)
 			break
 		}
 	}
@@ -70,7 +101,17 @@ func (c *Event) RemoveListener(listener IEventListener) {
 //		- correlationId: string (optional) transaction id to trace execution through call chain.
 //		- args: Parameters the parameters to raise this event with.
 func (c *Event) Notify(ctx context.Context, correlationId string, args *run.Parameters) {
-	for _, listener := range c.listeners {
+	c.mtx.RLock()
+	listeners := c.listeners
+	dispatcher := c.dispatcher
+	c.mtx.RUnlock()
+
+	if dispatcher != nil {
+		dispatcher.Dispatch(ctx, correlationId, c, listeners, args)
+		return
+	}
+
+	for _, listener := range listeners {
 		listener.OnEvent(ctx, correlationId, c, args)
 	}
 }
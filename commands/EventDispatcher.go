@@ -0,0 +1,329 @@
+package commands
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sync"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// EventOrdering defines which notifications are guaranteed to be delivered in order by EventDispatcher.
+//	Possible values:
+//		- NoOrdering - notifications are delivered by any free worker without ordering guarantees (default)
+//		- OrderPerListener - notifications are delivered to each listener in the order they were fired
+//		- OrderPerCorrelationId - notifications with the same correlation id are delivered in the order they were fired
+type EventOrdering int
+
+const (
+	NoOrdering EventOrdering = iota
+	OrderPerListener
+	OrderPerCorrelationId
+)
+
+// EventErrorHandler is a callback that receives errors raised by event listeners.
+type EventErrorHandler func(ctx context.Context, correlationId string, event IEvent, listener IEventListener, err error)
+
+// EventDispatcher delivers events to listeners asynchronously using a bounded pool of workers.
+// Panics raised by listeners are recovered and passed to the error handler as InvocationError,
+// so one failing or slow listener does not crash or stall the publisher.
+// Without ordering notifications are put into a queue shared by all workers, so any free worker
+// delivers them. With ordering each worker has its own queue, and notifications that must be
+// delivered in order are put into the same queue. When the queue is full, the publisher waits
+// until there is free space.
+//	see IEventDispatcher
+//	see EventOrdering
+//	Example:
+//		dispatcher := NewEventDispatcher(4, 100).
+//			WithOrdering(OrderPerListener).
+//			WithErrorHandler(func(ctx context.Context, correlationId string, event IEvent, listener IEventListener, err error) {
+//				fmt.Println(err)
+//			})
+//
+//		event := NewEvent("my_event")
+//		event.SetDispatcher(dispatcher)
+//		event.Notify(context.Background(), "123", nil)
+//
+//		dispatcher.Close(context.Background())
+type EventDispatcher struct {
+	mtx        sync.RWMutex
+	optionsMtx sync.RWMutex
+	queues     []chan *eventTask
+	shared     chan *eventTask
+	ordering   EventOrdering
+	onError    EventErrorHandler
+	closed     bool
+	closeOnce  sync.Once
+	workers    sync.WaitGroup
+	senders    sync.WaitGroup
+	pendingMtx sync.Mutex
+	pending    int
+	waiters    []chan struct{}
+}
+
+type eventTask struct {
+	ctx           context.Context
+	correlationId string
+	event         IEvent
+	listener      IEventListener
+	args          *run.Parameters
+}
+
+// NewEventDispatcher creates a new asynchronous event dispatcher and starts its workers.
+//	Parameters:
+//		- workers: int a number of workers. Values less than 1 are replaced with 1.
+//		- queueSize: int a size of the queue of each worker. The shared queue holds queueSize items per worker.
+//	Returns: *EventDispatcher
+func NewEventDispatcher(workers int, queueSize int) *EventDispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	c := &EventDispatcher{
+		queues: make([]chan *eventTask, workers),
+		shared: make(chan *eventTask, workers*queueSize),
+	}
+
+	for i := range c.queues {
+		c.queues[i] = make(chan *eventTask, queueSize)
+		c.workers.Add(1)
+		go c.work(c.queues[i], c.shared)
+	}
+
+	return c
+}
+
+// WithOrdering sets ordering guarantees for delivered notifications.
+//	Parameters: ordering: EventOrdering the ordering mode
+//	Returns: *EventDispatcher
+func (c *EventDispatcher) WithOrdering(ordering EventOrdering) *EventDispatcher {
+	c.optionsMtx.Lock()
+	defer c.optionsMtx.Unlock()
+
+	c.ordering = ordering
+	return c
+}
+
+// WithErrorHandler sets a callback that receives errors raised by listeners.
+//	Parameters: handler: EventErrorHandler the error callback
+//	Returns: *EventDispatcher
+func (c *EventDispatcher) WithErrorHandler(handler EventErrorHandler) *EventDispatcher {
+	c.optionsMtx.Lock()
+	defer c.optionsMtx.Unlock()
+
+	c.onError = handler
+	return c
+}
+
+// Dispatch enqueues delivery of the fired event to each listener.
+// If the dispatcher is closed, the error handler receives InvalidStateError for each listener.
+//	Parameters:
+//		- ctx context.Context - operation context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- event: IEvent the fired event
+//		- listeners: []IEventListener a snapshot of listeners to notify
+//		- args: *run.Parameters event arguments
+func (c *EventDispatcher) Dispatch(ctx context.Context, correlationId string, event IEvent,
+	listeners []IEventListener, args *run.Parameters) {
+
+	// The lock is not held while waiting for free space in the queues,
+	// so listeners can fire events and Close does not block other publishers
+	c.mtx.RLock()
+	closed := c.closed
+	queues := c.queues
+	if !closed {
+		c.senders.Add(1)
+	}
+	c.mtx.RUnlock()
+
+	if closed {
+		for _, listener := range listeners {
+			err := errors.NewInvalidStateError(correlationId, "DISPATCHER_CLOSED", "Event dispatcher is closed").
+				WithDetails("event", event.Name())
+			c.handleError(ctx, correlationId, event, listener, err)
+		}
+		return
+	}
+	defer c.senders.Done()
+
+	for _, listener := range listeners {
+		c.pendingMtx.Lock()
+		c.pending++
+		c.pendingMtx.Unlock()
+
+		c.selectQueue(queues, correlationId, listener) <- &eventTask{
+			ctx:           ctx,
+			correlationId: correlationId,
+			event:         event,
+			listener:      listener,
+			args:          args,
+		}
+	}
+}
+
+// selectQueue selects a queue according to the ordering mode.
+func (c *EventDispatcher) selectQueue(queues []chan *eventTask, correlationId string,
+	listener IEventListener) chan *eventTask {
+
+	count := uint32(len(queues))
+
+	c.optionsMtx.RLock()
+	ordering := c.ordering
+	c.optionsMtx.RUnlock()
+
+	switch ordering {
+	case OrderPerListener:
+		return queues[listenerHash(listener)%count]
+	case OrderPerCorrelationId:
+		hash := fnv.New32a()
+		hash.Write([]byte(correlationId))
+		return queues[hash.Sum32()%count]
+	default:
+		return c.shared
+	}
+}
+
+// listenerHash calculates a stable hash of the listener identity.
+// Pointer-like listeners are hashed by their address, other comparable values by their content.
+func listenerHash(listener IEventListener) uint32 {
+	if listener == nil {
+		return 0
+	}
+
+	hash := fnv.New32a()
+	value := reflect.ValueOf(listener)
+	switch value.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan, reflect.Map, reflect.Func:
+		var buffer [8]byte
+		binary.LittleEndian.PutUint64(buffer[:], uint64(value.Pointer()))
+		hash.Write(buffer[:])
+	default:
+		hash.Write([]byte(fmt.Sprintf("%T:%v", listener, listener)))
+	}
+	return hash.Sum32()
+}
+
+// work delivers notifications from the worker queue and the shared queue until both are closed.
+func (c *EventDispatcher) work(queue chan *eventTask, shared chan *eventTask) {
+	defer c.workers.Done()
+
+	for queue != nil || shared != nil {
+		var task *eventTask
+		var ok bool
+		select {
+		case task, ok = <-queue:
+			if !ok {
+				queue = nil
+				continue
+			}
+		case task, ok = <-shared:
+			if !ok {
+				shared = nil
+				continue
+			}
+		}
+		c.deliver(task)
+		c.completeTask()
+	}
+}
+
+func (c *EventDispatcher) deliver(task *eventTask) {
+	defer func() {
+		if r := recover(); r != nil {
+			message := convert.StringConverter.ToString(r)
+			err := errors.NewInvocationError(
+				task.correlationId,
+				"EVENT_FAILED",
+				"Notification of event "+task.event.Name()+" failed: "+message,
+			).WithDetails("event", task.event.Name())
+			if cause, ok := r.(error); ok {
+				err.WithCause(cause)
+			}
+			c.handleError(task.ctx, task.correlationId, task.event, task.listener, err)
+		}
+	}()
+
+	task.listener.OnEvent(task.ctx, task.correlationId, task.event, task.args)
+}
+
+func (c *EventDispatcher) handleError(ctx context.Context, correlationId string, event IEvent, listener IEventListener, err error) {
+	c.optionsMtx.RLock()
+	onError := c.onError
+	c.optionsMtx.RUnlock()
+
+	if onError != nil {
+		onError(ctx, correlationId, event, listener, err)
+	}
+}
+
+func (c *EventDispatcher) completeTask() {
+	c.pendingMtx.Lock()
+	defer c.pendingMtx.Unlock()
+
+	c.pending--
+	if c.pending == 0 {
+		for _, waiter := range c.waiters {
+			close(waiter)
+		}
+		c.waiters = nil
+	}
+}
+
+// Flush waits until all enqueued notifications are delivered.
+//	Parameters: ctx context.Context - operation context to cancel waiting
+//	Returns: error the context error if waiting was cancelled
+func (c *EventDispatcher) Flush(ctx context.Context) error {
+	c.pendingMtx.Lock()
+	if c.pending == 0 {
+		c.pendingMtx.Unlock()
+		return nil
+	}
+	waiter := make(chan struct{})
+	c.waiters = append(c.waiters, waiter)
+	c.pendingMtx.Unlock()
+
+	select {
+	case <-waiter:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting new notifications, delivers already enqueued ones and stops the workers.
+// Publishers that are waiting for free space in the queues complete their notifications before the workers stop.
+//	Parameters: ctx context.Context - operation context to cancel waiting
+//	Returns: error the context error if waiting was cancelled
+func (c *EventDispatcher) Close(ctx context.Context) error {
+	c.mtx.Lock()
+	c.closed = true
+	c.mtx.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.senders.Wait()
+		c.closeOnce.Do(func() {
+			for _, queue := range c.queues {
+				close(queue)
+			}
+			close(c.shared)
+		})
+		c.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
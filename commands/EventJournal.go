@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// EventJournalErrorHandler is a callback that receives errors raised while events are recorded.
+type EventJournalErrorHandler func(ctx context.Context, correlationId string, event string, err error)
+
+// EventJournal records fired events into a store and replays them into listeners.
+// It is used to rebuild projections and to investigate incidents.
+// When it is set to CommandSet, all events fired by CommandSet.Notify are recorded
+// before they are delivered to listeners.
+//	see IEventStore
+//	see CommandSet.SetEventJournal
+//	Example:
+//		journal := NewEventJournal(NewFileEventStore("./events.jsonl")).
+//			WithErrorHandler(func(ctx context.Context, correlationId string, event string, err error) {
+//				fmt.Println(err)
+//			})
+//		commandSet.SetEventJournal(journal)
+//
+//		// Replay events of the last hour into a projection
+//		journal.Replay(context.Background(), &EventFilter{FromTime: time.Now().Add(-time.Hour)}, projection)
+type EventJournal struct {
+	store   IEventStore
+	onError EventJournalErrorHandler
+}
+
+// NewEventJournal creates a new event journal.
+//	Parameters: store IEventStore a store for event records
+//	Returns: *EventJournal
+func NewEventJournal(store IEventStore) *EventJournal {
+	if store == nil {
+		panic("Store cannot be nil")
+	}
+
+	return &EventJournal{
+		store: store,
+	}
+}
+
+// WithErrorHandler sets a callback that receives errors raised while events are recorded by CommandSet.
+//	Parameters: handler EventJournalErrorHandler the error callback
+//	Returns: *EventJournal
+func (c *EventJournal) WithErrorHandler(handler EventJournalErrorHandler) *EventJournal {
+	c.onError = handler
+	return c
+}
+
+// Store gets the store of event records.
+//	Returns: IEventStore
+func (c *EventJournal) Store() IEventStore {
+	return c.store
+}
+
+// Record records the fired event.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- event: string a name of the fired event
+//		- args: *run.Parameters event arguments
+//	Returns: error
+func (c *EventJournal) Record(ctx context.Context, correlationId string, event string, args *run.Parameters) error {
+	record, err := NewEventRecord(event, correlationId, args)
+	if err == nil {
+		err = c.store.Append(ctx, record)
+	}
+
+	if err != nil && c.onError != nil {
+		c.onError(ctx, correlationId, event, err)
+	}
+	return err
+}
+
+// Read reads recorded events that match the filter.
+//	Parameters:
+//		- ctx context.Context
+//		- filter: *EventFilter (optional) criteria to select records.
+//	Returns: ([]*EventRecord, error)
+func (c *EventJournal) Read(ctx context.Context, filter *EventFilter) ([]*EventRecord, error) {
+	return c.store.Read(ctx, filter)
+}
+
+// Replay notifies listeners about recorded events that match the filter in the order they were recorded.
+//	Parameters:
+//		- ctx context.Context
+//		- filter: *EventFilter (optional) criteria to select records.
+//		- listeners: ...IEventListener listeners to notify
+//	Returns: (int, error) a number of replayed events
+func (c *EventJournal) Replay(ctx context.Context, filter *EventFilter, listeners ...IEventListener) (int, error) {
+	return c.replay(ctx, filter, func(ctx context.Context, record *EventRecord, args *run.Parameters) {
+		event := NewEvent(record.Event)
+		for _, listener := range listeners {
+			listener.OnEvent(ctx, record.CorrelationId, event, args)
+		}
+	})
+}
+
+func (c *EventJournal) replay(ctx context.Context, filter *EventFilter,
+	notify func(ctx context.Context, record *EventRecord, args *run.Parameters)) (int, error) {
+
+	records, err := c.store.Read(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	for index, record := range records {
+		if err := ctx.Err(); err != nil {
+			return index, err
+		}
+
+		args, err := record.GetArgs()
+		if err != nil {
+			return index, err
+		}
+		notify(ctx, record, args)
+	}
+	return len(records), nil
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// EventRecord is a fired event recorded in the event journal.
+//
+//	event - a name of the fired event
+//	correlation_id - a transaction id the event was fired with
+//	time - a time when the event was fired
+//	args - event arguments serialized into JSON
+type EventRecord struct {
+	Event         string    `json:"event"`
+	CorrelationId string    `json:"correlation_id"`
+	Time          time.Time `json:"time"`
+	Args          string    `json:"args"`
+}
+
+// NewEventRecord creates a new record of the fired event with serialized arguments.
+//	Parameters:
+//		- event: string a name of the fired event
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- args: *run.Parameters event arguments
+//	Returns: (*EventRecord, error) the record or error if the arguments cannot be serialized
+func NewEventRecord(event string, correlationId string, args *run.Parameters) (*EventRecord, error) {
+	record := &EventRecord{
+		Event:         event,
+		CorrelationId: correlationId,
+		Time:          time.Now().UTC(),
+	}
+
+	if args != nil {
+		json, err := convert.JsonConverter.ToJson(args.Value())
+		if err != nil {
+			return nil, err
+		}
+		record.Args = json
+	}
+	return record, nil
+}
+
+// GetArgs deserializes event arguments.
+// Numbers are restored as float64 values, like any other JSON numbers.
+//	Returns: (*run.Parameters, error) event arguments or error if they cannot be deserialized
+func (c *EventRecord) GetArgs() (*run.Parameters, error) {
+	if c.Args == "" {
+		return run.NewEmptyParameters(), nil
+	}
+
+	value, err := convert.JsonConverter.FromJson(c.Args)
+	if err != nil {
+		return nil, err
+	}
+	return run.NewParametersFromValue(value), nil
+}
+
+// EventFilter defines criteria to select records from the event journal.
+// Empty fields do not restrict the selection.
+//
+//	from_time - the earliest time of selected records, inclusive
+//	to_time - the latest time of selected records, exclusive
+//	event - a name of selected events
+//	correlation_id - a transaction id of selected events
+type EventFilter struct {
+	FromTime      time.Time `json:"from_time"`
+	ToTime        time.Time `json:"to_time"`
+	Event         string    `json:"event"`
+	CorrelationId string    `json:"correlation_id"`
+}
+
+// Match checks if the record matches the filter.
+//	Parameters: record *EventRecord a record to check
+//	Returns: bool true if the record matches the filter or the filter is nil
+func (c *EventFilter) Match(record *EventRecord) bool {
+	if c == nil {
+		return true
+	}
+	if !c.FromTime.IsZero() && record.Time.Before(c.FromTime) {
+		return false
+	}
+	if !c.ToTime.IsZero() && !record.Time.Before(c.ToTime) {
+		return false
+	}
+	if c.Event != "" && c.Event != record.Event {
+		return false
+	}
+	if c.CorrelationId != "" && c.CorrelationId != record.CorrelationId {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// EventNameMatcher checks if an event name matches a subscription.
+type EventNameMatcher func(name string) bool
+
+// EventPredicate checks if event arguments match a subscription.
+type EventPredicate func(args *run.Parameters) bool
+
+// GlobEventMatcher creates a matcher of event names by a glob pattern.
+// "*" matches any sequence of characters and "?" matches any single character,
+// so "order.*" matches "order.created" and "order.item.added".
+//	Parameters: pattern string a glob pattern
+//	Returns: EventNameMatcher
+func GlobEventMatcher(pattern string) EventNameMatcher {
+	return compileGlob(pattern).MatchString
+}
+
+// compileGlob converts a glob pattern with "*" and "?" wildcards into a regular expression.
+func compileGlob(pattern string) *regexp.Regexp {
+	expression := regexp.QuoteMeta(pattern)
+	expression = strings.ReplaceAll(expression, `\*`, ".*")
+	expression = strings.ReplaceAll(expression, `\?`, ".")
+	return regexp.MustCompile("^" + expression + "$")
+}
+
+// RegexEventMatcher creates a matcher of event names by a regular expression.
+// The expression must match the whole name.
+//	Parameters: pattern string a regular expression
+//	Returns: (EventNameMatcher, error) the matcher or error if the expression is invalid
+func RegexEventMatcher(pattern string) (EventNameMatcher, error) {
+	regex, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, err
+	}
+	return regex.MatchString, nil
+}
+
+// EventSubscription is a subscription of a listener to events of a CommandSet
+// selected by event names and arguments. It covers events added to the CommandSet
+// after the subscription was made.
+//	see CommandSet.Subscribe
+type EventSubscription struct {
+	commandSet *CommandSet
+	matcher    EventNameMatcher
+	predicate  EventPredicate
+	listener   IEventListener
+}
+
+// Matches checks if events with the name are covered by the subscription.
+//	Parameters: name string an event name
+//	Returns: bool
+func (c *EventSubscription) Matches(name string) bool {
+	return c.matcher == nil || c.matcher(name)
+}
+
+// OnEvent notifies the subscribed listener if event arguments match the predicate.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- event: IEvent the fired event
+//		- args: *run.Parameters event arguments
+func (c *EventSubscription) OnEvent(ctx context.Context, correlationId string, event IEvent, args *run.Parameters) {
+	if c.predicate != nil && !c.predicate(args) {
+		return
+	}
+	c.listener.OnEvent(ctx, correlationId, event, args)
+}
+
+// Unsubscribe removes the subscription from all events of the CommandSet.
+func (c *EventSubscription) Unsubscribe() {
+	c.commandSet.unsubscribe(c)
+}
@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+)
+
+// FileEventStore is an event journal store that appends records to a local file.
+// Records are written as JSON lines, one record per line, and the file is never rewritten.
+// The file is kept open between appends and flushed to disk after each append unless sync is disabled.
+// Reads do not block appends: they scan the file up to its size at the start of the read.
+// A record torn by a failure in the middle of an append has no trailing line break.
+// Such a record was never acknowledged, so reads skip it and the next append truncates it.
+//	see IEventStore
+//	Example:
+//		store := NewFileEventStore("./data/events.jsonl")
+//		defer store.Close(context.Background(), "")
+//		commandSet.SetEventJournal(NewEventJournal(store))
+type FileEventStore struct {
+	mtx  sync.Mutex
+	path string
+	sync bool
+	file *os.File
+}
+
+// NewFileEventStore creates a new file event store.
+//	Parameters: path string a path to the journal file. The file is created on the first append.
+//	Returns: *FileEventStore
+func NewFileEventStore(path string) *FileEventStore {
+	if path == "" {
+		panic("Path cannot be empty")
+	}
+
+	return &FileEventStore{
+		path: path,
+		sync: true,
+	}
+}
+
+// WithSync sets if appended records are flushed to disk before Append returns.
+// Disabling sync makes appends faster, but records can be lost on system failures.
+//	Parameters: sync bool true to flush records on each append (default: true)
+//	Returns: *FileEventStore
+func (c *FileEventStore) WithSync(sync bool) *FileEventStore {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.sync = sync
+	return c
+}
+
+// Path gets the path to the journal file.
+//	Returns: string
+func (c *FileEventStore) Path() string {
+	return c.path
+}
+
+// Append appends a record to the journal file.
+//	Parameters:
+//		- ctx context.Context
+//		- record: *EventRecord a record to append.
+//	Returns: error
+func (c *FileEventStore) Append(ctx context.Context, record *EventRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.file == nil {
+		file, err := os.OpenFile(c.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+		if err == nil {
+			err = truncateTornRecord(file)
+			if err != nil {
+				file.Close()
+			}
+		}
+		if err != nil {
+			return errors.NewFileError(record.CorrelationId, "JOURNAL_WRITE_FAILED", "Failed to open event journal "+c.path).
+				WithDetails("path", c.path).
+				WithCause(err)
+		}
+		c.file = file
+	}
+
+	if _, err = c.file.Write(line); err != nil {
+		return errors.NewFileError(record.CorrelationId, "JOURNAL_WRITE_FAILED", "Failed to write event journal "+c.path).
+			WithDetails("path", c.path).
+			WithCause(err)
+	}
+	if c.sync {
+		if err = c.file.Sync(); err != nil {
+			return errors.NewFileError(record.CorrelationId, "JOURNAL_WRITE_FAILED", "Failed to flush event journal "+c.path).
+				WithDetails("path", c.path).
+				WithCause(err)
+		}
+	}
+	return nil
+}
+
+// Close closes the journal file. The file is opened again on the next append.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//	Returns: error
+func (c *FileEventStore) Close(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.file == nil {
+		return nil
+	}
+
+	err := c.file.Close()
+	c.file = nil
+	if err != nil {
+		return errors.NewFileError(correlationId, "JOURNAL_WRITE_FAILED", "Failed to close event journal "+c.path).
+			WithDetails("path", c.path).
+			WithCause(err)
+	}
+	return nil
+}
+
+// Read reads records that match the filter in the order they were appended.
+// A missing file is treated as an empty journal.
+//	Parameters:
+//		- ctx context.Context
+//		- filter: *EventFilter (optional) criteria to select records.
+//	Returns: ([]*EventRecord, error)
+func (c *FileEventStore) Read(ctx context.Context, filter *EventFilter) ([]*EventRecord, error) {
+	result := []*EventRecord{}
+
+	// Records are appended completely under the lock, so the size taken under it
+	// points to the end of the last complete record and the scan can go without the lock
+	c.mtx.Lock()
+	info, err := os.Stat(c.path)
+	c.mtx.Unlock()
+
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, errors.NewFileError("", "JOURNAL_READ_FAILED", "Failed to open event journal "+c.path).
+			WithDetails("path", c.path).
+			WithCause(err)
+	}
+
+	file, err := os.Open(c.path)
+	if err != nil {
+		return nil, errors.NewFileError("", "JOURNAL_READ_FAILED", "Failed to open event journal "+c.path).
+			WithDetails("path", c.path).
+			WithCause(err)
+	}
+	defer file.Close()
+
+	size, err := completeSize(file, info.Size())
+	if err != nil {
+		return nil, errors.NewFileError("", "JOURNAL_READ_FAILED", "Failed to read event journal "+c.path).
+			WithDetails("path", c.path).
+			WithCause(err)
+	}
+
+	scanner := bufio.NewScanner(io.LimitReader(file, size))
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		record := &EventRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, errors.NewFileError("", "JOURNAL_READ_FAILED", "Event journal "+c.path+" is corrupted").
+				WithDetails("path", c.path).
+				WithDetails("line", lineNumber).
+				WithCause(err)
+		}
+		if filter.Match(record) {
+			result = append(result, record)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.NewFileError("", "JOURNAL_READ_FAILED", "Failed to read event journal "+c.path).
+			WithDetails("path", c.path).
+			WithCause(err)
+	}
+	return result, nil
+}
+
+// completeSize gets the size of the journal up to the line break after the last complete record.
+func completeSize(file *os.File, size int64) (int64, error) {
+	buffer := make([]byte, 4096)
+	for end := size; end > 0; {
+		start := end - int64(len(buffer))
+		if start < 0 {
+			start = 0
+		}
+		chunk := buffer[:end-start]
+		if _, err := file.ReadAt(chunk, start); err != nil {
+			return 0, err
+		}
+		if index := bytes.LastIndexByte(chunk, '\n'); index >= 0 {
+			return start + int64(index) + 1, nil
+		}
+		end = start
+	}
+	return 0, nil
+}
+
+// truncateTornRecord removes a torn record from the end of the journal, so appended records start on a new line.
+func truncateTornRecord(file *os.File) error {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	size, err := completeSize(file, info.Size())
+	if err != nil || size == info.Size() {
+		return err
+	}
+	return file.Truncate(size)
+}
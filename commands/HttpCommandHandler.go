@@ -0,0 +1,297 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// JSON-RPC 2.0 error codes as defined in https://www.jsonrpc.org/specification.
+const (
+	JsonRpcParseError     = -32700
+	JsonRpcInvalidRequest = -32600
+	JsonRpcMethodNotFound = -32601
+	JsonRpcInvalidParams  = -32602
+	JsonRpcServerError    = -32000
+)
+
+// HttpCommandHandler is a net/http handler that serves commands of an ICommandable.
+//
+// Commands are called by "POST <basePath>/<command>" with a JSON object of arguments in the body.
+// Results are returned as JSON with status 200, or status 204 when the command returns nil.
+// Errors are returned as ErrorDescription with HTTP status mapped from the error category.
+//
+// JSON-RPC 2.0 requests, single or batched, are accepted by "POST <basePath>/". Methods are command names
+// and params are objects of arguments. Errors contain ErrorDescription in the data field.
+//
+// A correlation id is read from "correlation_id" query parameter or "X-Correlation-Id" header.
+// Request bodies are limited to 1MB by default, larger requests are rejected with status 413.
+//	see RemoteCommand
+//	Example:
+//		handler := NewHttpCommandHandler(controller).WithBasePath("/v1/orders")
+//		http.Handle("/v1/orders/", handler)
+//
+//		// curl -X POST localhost:8080/v1/orders/get_order -d '{"id": "1"}'
+//		// curl -X POST localhost:8080/v1/orders/ -d '{"jsonrpc": "2.0", "method": "get_order", "params": {"id": "1"}, "id": 1}'
+type HttpCommandHandler struct {
+	commandable ICommandable
+	basePath    string
+	maxBodySize int64
+}
+
+const defaultMaxBodySize = 1024 * 1024
+
+// NewHttpCommandHandler creates a new handler for commands of the commandable object.
+//	Parameters: commandable ICommandable an object with a command set to serve
+//	Returns: *HttpCommandHandler
+func NewHttpCommandHandler(commandable ICommandable) *HttpCommandHandler {
+	if commandable == nil {
+		panic("Commandable cannot be nil")
+	}
+
+	return &HttpCommandHandler{
+		commandable: commandable,
+		maxBodySize: defaultMaxBodySize,
+	}
+}
+
+// WithBasePath sets a path prefix of command routes.
+//	Parameters: basePath string a path prefix, for instance "/v1/orders"
+//	Returns: *HttpCommandHandler
+func (c *HttpCommandHandler) WithBasePath(basePath string) *HttpCommandHandler {
+	c.basePath = strings.TrimRight(basePath, "/")
+	return c
+}
+
+// WithMaxBodySize sets a maximum size of request bodies.
+//	Parameters: maxBodySize int64 a maximum size in bytes, 0 for unlimited (default: 1MB)
+//	Returns: *HttpCommandHandler
+func (c *HttpCommandHandler) WithMaxBodySize(maxBodySize int64) *HttpCommandHandler {
+	c.maxBodySize = maxBodySize
+	return c
+}
+
+// ServeHTTP handles a command or JSON-RPC request.
+//	Parameters:
+//		- w http.ResponseWriter
+//		- r *http.Request
+func (c *HttpCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	correlationId := r.URL.Query().Get("correlation_id")
+	if correlationId == "" {
+		correlationId = r.Header.Get("X-Correlation-Id")
+	}
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		c.writeError(w, errors.NewUnsupportedError(correlationId, "METHOD_NOT_ALLOWED",
+			"Method "+r.Method+" is not allowed").
+			WithDetails("method", r.Method).
+			WithStatus(http.StatusMethodNotAllowed))
+		return
+	}
+
+	path := r.URL.Path
+	if !strings.HasPrefix(path, c.basePath+"/") {
+		c.writeError(w, errors.NewNotFoundError(correlationId, "NOT_FOUND", "Path "+path+" is not found").
+			WithDetails("path", path))
+		return
+	}
+
+	reader := r.Body
+	if c.maxBodySize > 0 {
+		reader = http.MaxBytesReader(w, r.Body, c.maxBodySize)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil && c.maxBodySize > 0 && int64(len(body)) >= c.maxBodySize {
+		c.writeError(w, errors.NewBadRequestError(correlationId, "BODY_TOO_LARGE",
+			"Request body exceeds "+strconv.FormatInt(c.maxBodySize, 10)+" bytes").
+			WithDetails("max_size", c.maxBodySize).
+			WithStatus(http.StatusRequestEntityTooLarge).
+			WithCause(err))
+		return
+	}
+	if err != nil {
+		c.writeError(w, errors.NewBadRequestError(correlationId, "INVALID_BODY", "Failed to read request body").
+			WithCause(err))
+		return
+	}
+
+	command := strings.TrimPrefix(path, c.basePath+"/")
+	if command == "" {
+		c.serveJsonRpc(w, r, correlationId, body)
+		return
+	}
+
+	args := run.NewEmptyParameters()
+	if len(bytes.TrimSpace(body)) > 0 {
+		values := map[string]any{}
+		if err = json.Unmarshal(body, &values); err != nil {
+			c.writeError(w, errors.NewBadRequestError(correlationId, "INVALID_BODY",
+				"Request body is not a JSON object").WithCause(err))
+			return
+		}
+		args = run.NewParameters(values)
+	}
+
+	result, err := c.commandable.GetCommandSet().Execute(r.Context(), correlationId, command, args)
+	if err != nil {
+		c.writeError(w, err)
+		return
+	}
+	if result == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	c.writeJson(w, http.StatusOK, result)
+}
+
+func (c *HttpCommandHandler) writeJson(w http.ResponseWriter, status int, value any) {
+	buffer, err := json.Marshal(value)
+	if err != nil {
+		status = http.StatusInternalServerError
+		buffer, _ = json.Marshal(errors.ErrorDescriptionFactory.CreateExternal(
+			errors.NewInternalError("", "OUTPUT_FAILED", "Failed to serialize response").WithCause(err)))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(buffer)
+}
+
+func (c *HttpCommandHandler) writeError(w http.ResponseWriter, err error) {
+	c.writeJson(w, errors.ErrorStatusMapping.HttpStatusOf(err), errors.ErrorDescriptionFactory.CreateExternal(err))
+}
+
+// jsonRpcRequest is a JSON-RPC 2.0 request envelope.
+type jsonRpcRequest struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Id      json.RawMessage `json:"id,omitempty"`
+}
+
+// jsonRpcResponse is a JSON-RPC 2.0 response envelope.
+type jsonRpcResponse struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonRpcError   `json:"error,omitempty"`
+	Id      json.RawMessage `json:"id"`
+}
+
+// jsonRpcError is a JSON-RPC 2.0 error with ErrorDescription in data.
+type jsonRpcError struct {
+	Code    int                      `json:"code"`
+	Message string                   `json:"message"`
+	Data    *errors.ErrorDescription `json:"data,omitempty"`
+}
+
+func (c *HttpCommandHandler) serveJsonRpc(w http.ResponseWriter, r *http.Request, correlationId string, body []byte) {
+	body = bytes.TrimSpace(body)
+
+	if len(body) > 0 && body[0] == '[' {
+		requests := []json.RawMessage{}
+		if err := json.Unmarshal(body, &requests); err != nil || len(requests) == 0 {
+			c.writeJson(w, http.StatusOK, c.newJsonRpcError(nil, JsonRpcInvalidRequest,
+				errors.NewBadRequestError(correlationId, "INVALID_REQUEST", "Invalid JSON-RPC batch")))
+			return
+		}
+
+		responses := []*jsonRpcResponse{}
+		for _, request := range requests {
+			if response := c.executeJsonRpc(r, correlationId, request); response != nil {
+				responses = append(responses, response)
+			}
+		}
+		if len(responses) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		c.writeJson(w, http.StatusOK, responses)
+		return
+	}
+
+	response := c.executeJsonRpc(r, correlationId, body)
+	if response == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	c.writeJson(w, http.StatusOK, response)
+}
+
+// executeJsonRpc executes a single JSON-RPC request. It returns nil for notifications.
+func (c *HttpCommandHandler) executeJsonRpc(r *http.Request, correlationId string, body []byte) *jsonRpcResponse {
+	request := jsonRpcRequest{}
+	if err := json.Unmarshal(body, &request); err != nil {
+		code := JsonRpcParseError
+		if json.Valid(body) {
+			code = JsonRpcInvalidRequest
+		}
+		return c.newJsonRpcError(nil, code,
+			errors.NewBadRequestError(correlationId, "INVALID_REQUEST", "Invalid JSON-RPC request").WithCause(err))
+	}
+	if request.JsonRpc != "2.0" || request.Method == "" {
+		return c.newJsonRpcError(request.Id, JsonRpcInvalidRequest,
+			errors.NewBadRequestError(correlationId, "INVALID_REQUEST", "Invalid JSON-RPC request"))
+	}
+
+	args := run.NewEmptyParameters()
+	if len(request.Params) > 0 && string(request.Params) != "null" {
+		values := map[string]any{}
+		if err := json.Unmarshal(request.Params, &values); err != nil {
+			return c.newJsonRpcError(request.Id, JsonRpcInvalidParams,
+				errors.NewBadRequestError(correlationId, "INVALID_ARGS", "JSON-RPC params must be an object").
+					WithCause(err))
+		}
+		args = run.NewParameters(values)
+	}
+
+	result, err := c.commandable.GetCommandSet().Execute(r.Context(), correlationId, request.Method, args)
+	if request.Id == nil {
+		return nil
+	}
+	if err != nil {
+		code := JsonRpcServerError
+		var appErr *errors.ApplicationError
+		if errors.As(err, &appErr) {
+			if appErr.Code == "CMD_NOT_FOUND" {
+				code = JsonRpcMethodNotFound
+			} else if appErr.Category == errors.BadRequest {
+				code = JsonRpcInvalidParams
+			}
+		}
+		return c.newJsonRpcError(request.Id, code, err)
+	}
+
+	buffer, err := json.Marshal(result)
+	if err != nil {
+		return c.newJsonRpcError(request.Id, JsonRpcServerError,
+			errors.NewInternalError(correlationId, "OUTPUT_FAILED", "Failed to serialize response").WithCause(err))
+	}
+	return &jsonRpcResponse{
+		JsonRpc: "2.0",
+		Result:  buffer,
+		Id:      request.Id,
+	}
+}
+
+func (c *HttpCommandHandler) newJsonRpcError(id json.RawMessage, code int, err error) *jsonRpcResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	description := errors.ErrorDescriptionFactory.CreateExternal(err)
+	return &jsonRpcResponse{
+		JsonRpc: "2.0",
+		Error: &jsonRpcError{
+			Code:    code,
+			Message: description.Message,
+			Data:    description,
+		},
+		Id: id,
+	}
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"context"
+)
+
+// ICommandAuthorizer an interface for command interceptors that check if callers may execute commands.
+// CommandSet calls authorizers before it validates command arguments,
+// so unauthorized callers do not get validation errors that reveal argument schemas.
+//	see AuthorizationInterceptor
+//	see CommandSet.AddInterceptor
+type ICommandAuthorizer interface {
+	// Authorize checks if the caller in the context may execute the command.
+	//	Parameters:
+	//		- ctx context.Context a context with caller principal
+	//		- correlationId: string (optional) transaction id to trace execution through call chain.
+	//		- command: string a registered command name
+	//	Returns: error an error if the caller may not execute the command or nil
+	Authorize(ctx context.Context, correlationId string, command string) error
+}
+
+type authorizedCommandKey struct{}
+
+// withAuthorizedCommand marks the command as authorized by CommandSet in the context,
+// so authorizers in the interceptor chain do not check it again.
+func withAuthorizedCommand(ctx context.Context, command string) context.Context {
+	return context.WithValue(ctx, authorizedCommandKey{}, command)
+}
+
+// isAuthorizedCommand checks if the command was already authorized by CommandSet.
+func isAuthorizedCommand(ctx context.Context, command string) bool {
+	name, ok := ctx.Value(authorizedCommandKey{}).(string)
+	return ok && name == command
+}
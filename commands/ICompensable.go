@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// ICompensable is an interface for commands that can undo their execution.
+// It is used by CommandSet.ExecuteBatch in BatchAllOrNothing mode to roll back
+// successfully executed commands when a later command in the batch fails.
+//	see CompensableCommand
+//	see CommandSet.ExecuteBatch
+type ICompensable interface {
+	// Compensate undoes a successful execution of the command.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- correlationId: string (optional) transaction id to trace execution through call chain.
+	//		- args: *run.Parameters the parameters (arguments) the command was executed with.
+	//		- result: any the result of the execution.
+	//	Returns: error
+	Compensate(ctx context.Context, correlationId string, args *run.Parameters, result any) error
+}
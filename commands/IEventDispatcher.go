@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// IEventDispatcher an interface for dispatchers that deliver fired events to listeners.
+// By default events notify their listeners synchronously on the caller's goroutine.
+// Dispatchers allow to change that, for instance, to deliver events asynchronously.
+//	see Event
+//	see EventDispatcher
+type IEventDispatcher interface {
+	// Dispatch delivers the fired event to the listeners.
+	//	Parameters:
+	//		- ctx context.Context - operation context
+	//		- correlationId: string (optional) transaction id to trace execution through call chain.
+	//		- event: IEvent the fired event
+	//		- listeners: []IEventListener a snapshot of listeners to notify
+	//		- args: *run.Parameters event arguments
+	Dispatch(ctx context.Context, correlationId string, event IEvent, listeners []IEventListener, args *run.Parameters)
+}
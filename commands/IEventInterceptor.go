@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// IEventInterceptor an interface for stackable event interceptors,
+// which can extend and modify notifications of CommandSet events before they reach listeners.
+// This mechanism can be used for tracing, enrichment of event arguments, and muting of events.
+//	see IEvent
+//	see InterceptedEvent
+//	see CommandSet.AddEventInterceptor
+type IEventInterceptor interface {
+	// Notify intercepts notification of the wrapped event.
+	// The interceptor shall call event.Notify to pass the notification further,
+	// possibly with altered context, correlation id or arguments.
+	// The notification is suppressed when event.Notify is not called.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- correlationId: string (optional) transaction id to trace execution through call chain.
+	//		- event: IEvent the next event in the call chain that is to be notified.
+	//		- args: *run.Parameters the event arguments.
+	Notify(ctx context.Context, correlationId string, event IEvent, args *run.Parameters)
+}
+
+// EventInterceptorFunc is an adapter to use ordinary functions as event interceptors.
+//	Example:
+//		// Mute noisy events
+//		commandSet.AddEventInterceptor(EventInterceptorFunc(
+//			func(ctx context.Context, correlationId string, event IEvent, args *run.Parameters) {
+//				if event.Name() != "heartbeat" {
+//					event.Notify(ctx, correlationId, args)
+//				}
+//			}))
+type EventInterceptorFunc func(ctx context.Context, correlationId string, event IEvent, args *run.Parameters)
+
+// Notify calls the function.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- event: IEvent the next event in the call chain that is to be notified.
+//		- args: *run.Parameters the event arguments.
+func (f EventInterceptorFunc) Notify(ctx context.Context, correlationId string, event IEvent, args *run.Parameters) {
+	f(ctx, correlationId, event, args)
+}
@@ -0,0 +1,23 @@
+package commands
+
+import "context"
+
+// IEventStore is an interface for storages of the event journal.
+//	see EventJournal
+//	see MemoryEventStore
+//	see FileEventStore
+type IEventStore interface {
+	// Append appends a record to the store.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- record: *EventRecord a record to append.
+	//	Returns: error
+	Append(ctx context.Context, record *EventRecord) error
+
+	// Read reads records that match the filter in the order they were appended.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- filter: *EventFilter (optional) criteria to select records.
+	//	Returns: ([]*EventRecord, error)
+	Read(ctx context.Context, filter *EventFilter) ([]*EventRecord, error)
+}
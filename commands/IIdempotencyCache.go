@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+)
+
+// IdempotencyRecord is a stored outcome of a command execution.
+//
+//	fingerprint - a hash of command arguments to detect key reuse with different arguments
+//	result - a result of the execution
+//	error - a description of ApplicationError returned by the execution, nil if the execution succeeded
+//	time - a time when the outcome was stored
+//	see IdempotencyInterceptor
+type IdempotencyRecord struct {
+	Fingerprint string                   `json:"fingerprint"`
+	Result      any                      `json:"result,omitempty"`
+	Error       *errors.ErrorDescription `json:"error,omitempty"`
+	Time        time.Time                `json:"time"`
+}
+
+// IIdempotencyCache is an interface for caches of command outcomes by idempotency keys.
+//	see IdempotencyInterceptor
+//	see MemoryIdempotencyCache
+type IIdempotencyCache interface {
+	// Get gets a stored outcome by a key.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- key: string an idempotency key.
+	//	Returns: (*IdempotencyRecord, error) the stored outcome or nil if it is not found or expired
+	Get(ctx context.Context, key string) (*IdempotencyRecord, error)
+
+	// Put stores an outcome by a key.
+	//	Parameters:
+	//		- ctx context.Context
+	//		- key: string an idempotency key.
+	//		- record: *IdempotencyRecord an outcome to store.
+	//	Returns: error
+	Put(ctx context.Context, key string, record *IdempotencyRecord) error
+}
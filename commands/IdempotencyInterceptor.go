@@ -0,0 +1,271 @@
+package commands
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	refl "reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// IdempotencyInterceptor prevents repeated execution of commands called with the same idempotency key.
+//
+// The key is read from the command arguments or, if it is not there, from the context.
+// The first execution stores its outcome in a cache: the result or ApplicationError.
+// Repeated calls with the same key and arguments get the stored outcome without execution.
+// Repeated calls with the same key and different arguments fail with ConflictError
+// with "IDEMPOTENCY_KEY_REUSED" code. Concurrent calls with the same key wait for the first one.
+// Calls without key are executed as usual.
+//
+// Keys are scoped by command names, tenants and caller principals from the context,
+// so callers cannot get outcomes of other callers by guessing their keys.
+// Stored and replayed results are deep copies, so callers can modify results without affecting later replays.
+// Retryable errors and errors that are not ApplicationError are not stored,
+// so the caller can retry the command with the same key.
+//
+// Configuration parameters:
+//
+//	idempotency.enabled: true to check idempotency keys (default: true)
+//	idempotency.key: a name of the argument with idempotency key (default: "idempotency_key")
+//	commands.<name>.idempotency.*: idempotency parameters for specific command
+//
+//	see run.WithIdempotencyKey
+//	see IIdempotencyCache
+//	Example:
+//		interceptor := NewIdempotencyInterceptor(NewMemoryIdempotencyCache(10000, 24*60*60*1000))
+//		commandSet.AddInterceptor(interceptor)
+//
+//		args := run.NewParametersFromTuples("idempotency_key", "req-1", "customer_id", "1")
+//		commandSet.Execute(ctx, "123", "create_order", args) // creates the order
+//		commandSet.Execute(ctx, "123", "create_order", args) // returns the same order
+type IdempotencyInterceptor struct {
+	interceptorBase
+	cache    IIdempotencyCache
+	mtx      sync.Mutex
+	inflight map[string]chan struct{}
+}
+
+// NewIdempotencyInterceptor creates a new idempotency interceptor.
+//	Parameters: cache IIdempotencyCache a cache to store command outcomes
+//	Returns: *IdempotencyInterceptor
+func NewIdempotencyInterceptor(cache IIdempotencyCache) *IdempotencyInterceptor {
+	if cache == nil {
+		panic("Cache cannot be nil")
+	}
+
+	return &IdempotencyInterceptor{
+		cache:    cache,
+		inflight: map[string]chan struct{}{},
+	}
+}
+
+// Execute executes the command or replays the stored outcome for repeated idempotency keys.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- command: ICommand the next command in the call chain
+//		- args: *run.Parameters the parameters (arguments) to pass to the command
+//	Returns: (any, error) the execution result
+func (c *IdempotencyInterceptor) Execute(ctx context.Context, correlationId string, command ICommand,
+	args *run.Parameters) (any, error) {
+
+	name := command.Name()
+	config := c.commandConfig(name)
+	if !config.GetAsBooleanWithDefault("idempotency.enabled", true) {
+		return command.Execute(ctx, correlationId, args)
+	}
+
+	keyName := config.GetAsStringWithDefault("idempotency.key", "idempotency_key")
+	key := ""
+	if args != nil {
+		key = args.GetAsString(keyName)
+	}
+	if key == "" {
+		key = run.GetIdempotencyKey(ctx)
+	}
+	if key == "" {
+		return command.Execute(ctx, correlationId, args)
+	}
+
+	fingerprint, err := c.fingerprint(args, keyName)
+	if err != nil {
+		return nil, errors.NewBadRequestError(correlationId, "INVALID_ARGS", "Failed to fingerprint command arguments").
+			WithDetails("command", name).
+			WithCause(err)
+	}
+
+	principalId := ""
+	if principal := run.GetPrincipal(ctx); principal != nil {
+		principalId = principal.Id
+	}
+	cacheKey := idempotencyKeyEscaper.Replace(name) + ":" + idempotencyKeyEscaper.Replace(run.GetTenant(ctx)) + ":" +
+		idempotencyKeyEscaper.Replace(principalId) + ":" + idempotencyKeyEscaper.Replace(key)
+	release, err := c.acquire(ctx, cacheKey)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	record, err := c.cache.Get(ctx, cacheKey)
+	if err != nil {
+		return nil, err
+	}
+	if record != nil {
+		if record.Fingerprint != fingerprint {
+			return nil, errors.NewConflictError(correlationId, "IDEMPOTENCY_KEY_REUSED",
+				"Idempotency key "+key+" was used with different arguments").
+				WithDetails("command", name).
+				WithDetails("key", key)
+		}
+		if record.Error != nil {
+			return nil, errors.ApplicationErrorFactory.Create(record.Error)
+		}
+		return copyValue(record.Result), nil
+	}
+
+	result, err := command.Execute(ctx, correlationId, args)
+
+	record = &IdempotencyRecord{
+		Fingerprint: fingerprint,
+		Time:        time.Now().UTC(),
+	}
+	if err != nil {
+		var appErr *errors.ApplicationError
+		if !errors.As(err, &appErr) || errors.IsRetryable(err) {
+			return nil, err
+		}
+		record.Error = errors.ErrorDescriptionFactory.CreateInternal(appErr)
+	} else {
+		record.Result = copyValue(result)
+	}
+
+	// The outcome is returned even if it cannot be stored
+	_ = c.cache.Put(ctx, cacheKey, record)
+	return result, err
+}
+
+// idempotencyKeyEscaper escapes separators in parts of cache keys,
+// so different combinations of parts cannot produce the same key.
+var idempotencyKeyEscaper = strings.NewReplacer("\\", "\\\\", ":", "\\:")
+
+// fingerprint calculates a hash of arguments without the idempotency key.
+// Map keys are serialized in sorted order, so equal arguments have equal fingerprints.
+func (c *IdempotencyInterceptor) fingerprint(args *run.Parameters, keyName string) (string, error) {
+	values := map[string]any{}
+	if args != nil {
+		for key, value := range args.Value() {
+			if key != keyName {
+				values[key] = value
+			}
+		}
+	}
+
+	buffer, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(buffer)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// acquire waits until other calls with the same key are completed and marks the key as in flight.
+func (c *IdempotencyInterceptor) acquire(ctx context.Context, key string) (func(), error) {
+	for {
+		c.mtx.Lock()
+		done, busy := c.inflight[key]
+		if !busy {
+			done = make(chan struct{})
+			c.inflight[key] = done
+			c.mtx.Unlock()
+
+			return func() {
+				c.mtx.Lock()
+				delete(c.inflight, key)
+				c.mtx.Unlock()
+				close(done)
+			}, nil
+		}
+		c.mtx.Unlock()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// copyValue makes a deep copy of maps, slices, arrays, pointers and exported struct fields.
+// Unexported struct fields are copied as is.
+func copyValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	return deepCopyValue(refl.ValueOf(value), map[uintptr]refl.Value{}).Interface()
+}
+
+func deepCopyValue(value refl.Value, visited map[uintptr]refl.Value) refl.Value {
+	switch value.Kind() {
+	case refl.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		// Keep shared and recursive references
+		if result, ok := visited[value.Pointer()]; ok {
+			return result
+		}
+		result := refl.New(value.Type().Elem())
+		visited[value.Pointer()] = result
+		result.Elem().Set(deepCopyValue(value.Elem(), visited))
+		return result
+	case refl.Interface:
+		if value.IsNil() {
+			return value
+		}
+		result := refl.New(value.Type()).Elem()
+		result.Set(deepCopyValue(value.Elem(), visited))
+		return result
+	case refl.Map:
+		if value.IsNil() {
+			return value
+		}
+		result := refl.MakeMapWithSize(value.Type(), value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			result.SetMapIndex(iterator.Key(), deepCopyValue(iterator.Value(), visited))
+		}
+		return result
+	case refl.Slice:
+		if value.IsNil() {
+			return value
+		}
+		result := refl.MakeSlice(value.Type(), value.Len(), value.Len())
+		for index := 0; index < value.Len(); index++ {
+			result.Index(index).Set(deepCopyValue(value.Index(index), visited))
+		}
+		return result
+	case refl.Array:
+		result := refl.New(value.Type()).Elem()
+		for index := 0; index < value.Len(); index++ {
+			result.Index(index).Set(deepCopyValue(value.Index(index), visited))
+		}
+		return result
+	case refl.Struct:
+		result := refl.New(value.Type()).Elem()
+		result.Set(value)
+		for index := 0; index < value.NumField(); index++ {
+			if result.Field(index).CanSet() {
+				result.Field(index).Set(deepCopyValue(value.Field(index), visited))
+			}
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// InterceptedEvent implements an event wrapped by an interceptor.
+// It allows building notification chains.
+// The interceptor can alter notifications and delegate them to a
+// next event, which can be intercepted or concrete.
+// Names and listeners are taken from the next event.
+//	see IEvent
+//	see IEventInterceptor
+type InterceptedEvent struct {
+	interceptor IEventInterceptor
+	next        IEvent
+}
+
+// NewInterceptedEvent creates a new InterceptedEvent, which serves as a link in a notification chain.
+//	Parameters:
+//		- interceptor: IEventInterceptor the interceptor that is intercepting the event.
+//		- next: IEvent (link to) the next event in the notification chain.
+//	Returns: *InterceptedEvent
+func NewInterceptedEvent(interceptor IEventInterceptor, next IEvent) *InterceptedEvent {
+	return &InterceptedEvent{
+		interceptor: interceptor,
+		next:        next,
+	}
+}
+
+// Name gets the name of the intercepted event.
+//	Returns: string the name of the event.
+func (c *InterceptedEvent) Name() string {
+	return c.next.Name()
+}
+
+// Listeners gets listeners of the intercepted event.
+//	Returns: []IEventListener a list of listeners.
+func (c *InterceptedEvent) Listeners() []IEventListener {
+	return c.next.Listeners()
+}
+
+// AddListener adds a listener to the intercepted event.
+//	Parameters: listener: IEventListener the listener reference to add.
+func (c *InterceptedEvent) AddListener(listener IEventListener) {
+	c.next.AddListener(listener)
+}
+
+// RemoveListener removes a listener from the intercepted event.
+//	Parameters: listener: IEventListener the listener reference to remove.
+func (c *InterceptedEvent) RemoveListener(listener IEventListener) {
+	c.next.RemoveListener(listener)
+}
+
+// Notify passes the notification to the interceptor with the next event in the chain.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- args: *run.Parameters the event arguments.
+func (c *InterceptedEvent) Notify(ctx context.Context, correlationId string, args *run.Parameters) {
+	c.interceptor.Notify(ctx, correlationId, c.next, args)
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+// interceptorBase implements common parts of built-in interceptors:
+// it passes names and validation to the intercepted command and resolves
+// configuration parameters for specific commands.
+//
+// Configuration parameters are taken from the root of the configuration
+// and can be overridden for specific commands in "commands.<name>" sections:
+//
+//	timeout: 5000
+//	commands.get_data.timeout: 1000
+type interceptorBase struct {
+	configMtx sync.RWMutex
+	config    *config.ConfigParams
+	resolved  map[string]*config.ConfigParams
+}
+
+// Name gets the name of the intercepted command.
+//	Parameters: command ICommand the intercepted command
+//	Returns: string the command name
+func (c *interceptorBase) Name(command ICommand) string {
+	return command.Name()
+}
+
+// Validate validates arguments using the intercepted command.
+//	Parameters:
+//		- command ICommand the intercepted command
+//		- args *run.Parameters command arguments
+//	Returns: []*validate.ValidationResult validation results
+func (c *interceptorBase) Validate(command ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(args)
+}
+
+// Configure configures the interceptor by passing configuration parameters.
+// Parameters for specific commands are set in "commands.<name>" sections.
+//	Parameters:
+//		- ctx context.Context
+//		- config: *config.ConfigParams configuration parameters to be set.
+func (c *interceptorBase) Configure(ctx context.Context, config *config.ConfigParams) {
+	c.configMtx.Lock()
+	defer c.configMtx.Unlock()
+
+	c.config = config
+	c.resolved = nil
+}
+
+// commandConfig gets configuration parameters for the command
+// with root parameters overridden by the command section.
+func (c *interceptorBase) commandConfig(name string) *config.ConfigParams {
+	c.configMtx.RLock()
+	if result, ok := c.resolved[name]; ok {
+		c.configMtx.RUnlock()
+		return result
+	}
+	c.configMtx.RUnlock()
+
+	c.configMtx.Lock()
+	defer c.configMtx.Unlock()
+
+	if c.config == nil {
+		c.config = config.NewEmptyConfigParams()
+	}
+	if c.resolved == nil {
+		c.resolved = map[string]*config.ConfigParams{}
+	}
+
+	result := c.config.Override(c.config.GetSection("commands." + name))
+	c.resolved[name] = result
+	return result
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryEventStore is an event journal store that keeps records in memory.
+// It is intended for testing and for services that do not need to keep records between restarts.
+// The store can be limited by a maximum number of records, in this case the oldest records are removed.
+//	see IEventStore
+type MemoryEventStore struct {
+	mtx        sync.RWMutex
+	records    []*EventRecord
+	maxRecords int
+}
+
+// NewMemoryEventStore creates a new in-memory event store.
+//	Parameters: maxRecords int a maximum number of kept records, 0 for unlimited
+//	Returns: *MemoryEventStore
+func NewMemoryEventStore(maxRecords int) *MemoryEventStore {
+	return &MemoryEventStore{
+		records:    []*EventRecord{},
+		maxRecords: maxRecords,
+	}
+}
+
+// Append appends a record to the store.
+//	Parameters:
+//		- ctx context.Context
+//		- record: *EventRecord a record to append.
+//	Returns: error
+func (c *MemoryEventStore) Append(ctx context.Context, record *EventRecord) error {
+	item := *record
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.records = append(c.records, &item)
+	if c.maxRecords > 0 && len(c.records) > c.maxRecords {
+		c.records = c.records[len(c.records)-c.maxRecords:]
+	}
+	return nil
+}
+
+// Read reads records that match the filter in the order they were appended.
+//	Parameters:
+//		- ctx context.Context
+//		- filter: *EventFilter (optional) criteria to select records.
+//	Returns: ([]*EventRecord, error)
+func (c *MemoryEventStore) Read(ctx context.Context, filter *EventFilter) ([]*EventRecord, error) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	result := []*EventRecord{}
+	for _, record := range c.records {
+		if filter.Match(record) {
+			item := *record
+			result = append(result, &item)
+		}
+	}
+	return result, nil
+}
+
+// Clear removes all records from the store.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//	Returns: error
+func (c *MemoryEventStore) Clear(ctx context.Context, correlationId string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.records = []*EventRecord{}
+	return nil
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryIdempotencyCache is a cache of command outcomes that keeps records in memory.
+// Records expire after the time to live and expired records are purged when new records are stored.
+// When the cache is full, the least recently used records are removed.
+//	see IIdempotencyCache
+type MemoryIdempotencyCache struct {
+	mtx         sync.Mutex
+	maxSize     int
+	ttl         int64
+	items       map[string]*list.Element
+	order       *list.List
+	expirations *list.List
+}
+
+type idempotencyCacheItem struct {
+	key        string
+	record     *IdempotencyRecord
+	expiration time.Time
+	expiry     *list.Element
+}
+
+// NewMemoryIdempotencyCache creates a new in-memory cache.
+//	Parameters:
+//		- maxSize: int a maximum number of kept records, 0 for unlimited
+//		- ttl: int64 a time to live of records in milliseconds, 0 to keep records until they are evicted
+//	Returns: *MemoryIdempotencyCache
+func NewMemoryIdempotencyCache(maxSize int, ttl int64) *MemoryIdempotencyCache {
+	return &MemoryIdempotencyCache{
+		maxSize:     maxSize,
+		ttl:         ttl,
+		items:       map[string]*list.Element{},
+		order:       list.New(),
+		expirations: list.New(),
+	}
+}
+
+// Get gets a stored outcome by a key.
+//	Parameters:
+//		- ctx context.Context
+//		- key: string an idempotency key.
+//	Returns: (*IdempotencyRecord, error) the stored outcome or nil if it is not found or expired
+func (c *MemoryIdempotencyCache) Get(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return nil, nil
+	}
+
+	item := element.Value.(*idempotencyCacheItem)
+	if !item.expiration.IsZero() && time.Now().After(item.expiration) {
+		c.remove(element)
+		return nil, nil
+	}
+
+	c.order.MoveToFront(element)
+	record := *item.record
+	return &record, nil
+}
+
+// Put stores an outcome by a key.
+//	Parameters:
+//		- ctx context.Context
+//		- key: string an idempotency key.
+//		- record: *IdempotencyRecord an outcome to store.
+//	Returns: error
+func (c *MemoryIdempotencyCache) Put(ctx context.Context, key string, record *IdempotencyRecord) error {
+	item := &idempotencyCacheItem{
+		key:    key,
+		record: record,
+	}
+	if c.ttl > 0 {
+		item.expiration = time.Now().Add(time.Duration(c.ttl) * time.Millisecond)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.purge()
+
+	if element, ok := c.items[key]; ok {
+		c.remove(element)
+	}
+
+	element := c.order.PushFront(item)
+	c.items[key] = element
+	if !item.expiration.IsZero() {
+		// Time to live is the same for all records, so records are added to the expiration list in order
+		item.expiry = c.expirations.PushBack(element)
+	}
+
+	if c.maxSize > 0 && c.order.Len() > c.maxSize {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+// purge removes expired records.
+func (c *MemoryIdempotencyCache) purge() {
+	now := time.Now()
+	for front := c.expirations.Front(); front != nil; front = c.expirations.Front() {
+		element := front.Value.(*list.Element)
+		if now.Before(element.Value.(*idempotencyCacheItem).expiration) {
+			return
+		}
+		c.remove(element)
+	}
+}
+
+// remove removes a record from the cache.
+func (c *MemoryIdempotencyCache) remove(element *list.Element) {
+	item := element.Value.(*idempotencyCacheItem)
+	c.order.Remove(element)
+	if item.expiry != nil {
+		c.expirations.Remove(item.expiry)
+	}
+	delete(c.items, item.key)
+}
+
+// Size gets the number of records in the cache, including expired records that are not purged yet.
+//	Returns: int
+func (c *MemoryIdempotencyCache) Size() int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.order.Len()
+}
+
+// Clear removes all records from the cache.
+func (c *MemoryIdempotencyCache) Clear() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.items = map[string]*list.Element{}
+	c.order.Init()
+	c.expirations.Init()
+}
@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"math"
+	refl "reflect"
+	"strings"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+// BindParameters binds parameters into a value of type T.
+// Struct fields are matched to parameters by json tags or field names, case insensitive.
+// Values are converted using the standard converters, so "123" is bound into int field,
+// and nested maps and arrays are bound into nested structs, maps and slices.
+// When conversion is not possible or a number does not fit into the field type,
+// it returns BadRequestError with "INVALID_ARGS" code.
+//	Example:
+//		type MyArgs struct {
+//			Id    string `json:"id"`
+//			Count int    `json:"count"`
+//		}
+//
+//		args, err := BindParameters[MyArgs]("123", run.NewParametersFromTuples("id", "1", "count", "5"))
+//		// args.Id == "1", args.Count == 5
+//	Parameters:
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- args: *run.Parameters parameters to bind
+//	Returns: (T, error) the bound value or error if binding failed
+func BindParameters[T any](correlationId string, args *run.Parameters) (T, error) {
+	var result T
+
+	var value any
+	if args != nil {
+		value = args.Value()
+	}
+
+	target := refl.ValueOf(&result).Elem()
+	if err := bindValue(target, value, ""); err != nil {
+		return result, errors.NewBadRequestError(correlationId, "INVALID_ARGS", err.Message).
+			WithDetails("property", err.Path)
+	}
+	return result, nil
+}
+
+type bindError struct {
+	Path    string
+	Message string
+}
+
+func newBindError(path string, target refl.Type, value any) *bindError {
+	name := path
+	if name == "" {
+		name = "value"
+	}
+	return &bindError{
+		Path:    path,
+		Message: name + " cannot be converted from " + refl.TypeOf(value).String() + " to " + target.String(),
+	}
+}
+
+func newRangeError(path string, target refl.Type) *bindError {
+	name := path
+	if name == "" {
+		name = "value"
+	}
+	return &bindError{
+		Path:    path,
+		Message: name + " is out of range of " + target.String(),
+	}
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func bindValue(target refl.Value, value any, path string) *bindError {
+	if value == nil {
+		return nil
+	}
+
+	typ := target.Type()
+	valueType := refl.TypeOf(value)
+	if valueType.AssignableTo(typ) {
+		target.Set(refl.ValueOf(value))
+		return nil
+	}
+
+	if typ == refl.TypeOf(time.Time{}) {
+		if v, ok := convert.DateTimeConverter.ToNullableDateTime(value); ok {
+			target.Set(refl.ValueOf(v))
+			return nil
+		}
+		return newBindError(path, typ, value)
+	}
+	if typ == refl.TypeOf(time.Duration(0)) {
+		if v, ok := convert.DurationConverter.ToNullableDuration(value); ok {
+			target.Set(refl.ValueOf(v))
+			return nil
+		}
+		return newBindError(path, typ, value)
+	}
+
+	switch typ.Kind() {
+	case refl.Ptr:
+		elem := refl.New(typ.Elem())
+		if err := bindValue(elem.Elem(), value, path); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+
+	case refl.Struct:
+		properties, ok := convert.MapConverter.ToNullableMap(value)
+		if !ok {
+			return newBindError(path, typ, value)
+		}
+		return bindStruct(target, properties, path)
+
+	case refl.Slice:
+		items, ok := convert.ArrayConverter.ToNullableArray(value)
+		if !ok {
+			return newBindError(path, typ, value)
+		}
+		slice := refl.MakeSlice(typ, len(items), len(items))
+		for index, item := range items {
+			if err := bindValue(slice.Index(index), item, joinPath(path, convert.StringConverter.ToString(index))); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+		return nil
+
+	case refl.Array:
+		items, ok := convert.ArrayConverter.ToNullableArray(value)
+		if !ok || len(items) > typ.Len() {
+			return newBindError(path, typ, value)
+		}
+		array := refl.New(typ).Elem()
+		for index, item := range items {
+			if err := bindValue(array.Index(index), item, joinPath(path, convert.StringConverter.ToString(index))); err != nil {
+				return err
+			}
+		}
+		target.Set(array)
+		return nil
+
+	case refl.Map:
+		if typ.Key().Kind() != refl.String {
+			return newBindError(path, typ, value)
+		}
+		properties, ok := convert.MapConverter.ToNullableMap(value)
+		if !ok {
+			return newBindError(path, typ, value)
+		}
+		result := refl.MakeMapWithSize(typ, len(properties))
+		for key, item := range properties {
+			elem := refl.New(typ.Elem()).Elem()
+			if err := bindValue(elem, item, joinPath(path, key)); err != nil {
+				return err
+			}
+			result.SetMapIndex(refl.ValueOf(key).Convert(typ.Key()), elem)
+		}
+		target.Set(result)
+		return nil
+
+	case refl.String, refl.Bool,
+		refl.Int, refl.Int8, refl.Int16, refl.Int32, refl.Int64,
+		refl.Uint, refl.Uint8, refl.Uint16, refl.Uint32, refl.Uint64,
+		refl.Float32, refl.Float64:
+		converted, ok := convert.TypeConverter.ToNullableType(convert.TypeConverter.ToTypeCode(typ), value)
+		if !ok || converted == nil || !refl.TypeOf(converted).ConvertibleTo(typ) {
+			return newBindError(path, typ, value)
+		}
+		if !isInRange(typ, value, refl.ValueOf(converted)) {
+			return newRangeError(path, typ)
+		}
+		target.Set(refl.ValueOf(converted).Convert(typ))
+		return nil
+	}
+
+	return newBindError(path, typ, value)
+}
+
+// isInRange checks if the converted number fits into the numeric type without overflow or sign loss.
+// Floats are checked using the original value, because conversion to float32 turns large values into infinity.
+func isInRange(typ refl.Type, value any, converted refl.Value) bool {
+	target := refl.New(typ).Elem()
+
+	switch typ.Kind() {
+	case refl.Int, refl.Int8, refl.Int16, refl.Int32, refl.Int64:
+		switch converted.Kind() {
+		case refl.Int, refl.Int8, refl.Int16, refl.Int32, refl.Int64:
+			return !target.OverflowInt(converted.Int())
+		case refl.Uint, refl.Uint8, refl.Uint16, refl.Uint32, refl.Uint64:
+			return converted.Uint() <= math.MaxInt64 && !target.OverflowInt(int64(converted.Uint()))
+		}
+	case refl.Uint, refl.Uint8, refl.Uint16, refl.Uint32, refl.Uint64:
+		switch converted.Kind() {
+		case refl.Int, refl.Int8, refl.Int16, refl.Int32, refl.Int64:
+			return converted.Int() >= 0 && !target.OverflowUint(uint64(converted.Int()))
+		case refl.Uint, refl.Uint8, refl.Uint16, refl.Uint32, refl.Uint64:
+			return !target.OverflowUint(converted.Uint())
+		}
+	case refl.Float32, refl.Float64:
+		if number, ok := convert.DoubleConverter.ToNullableDouble(value); ok {
+			return !target.OverflowFloat(number)
+		}
+	}
+	return true
+}
+
+func bindStruct(target refl.Value, properties map[string]any, path string) *bindError {
+	typ := target.Type()
+	for index := 0; index < typ.NumField(); index++ {
+		field := typ.Field(index)
+		name, _, ok := validate.ParseFieldTag(field)
+		if !ok {
+			continue
+		}
+
+		if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == refl.Struct {
+			if err := bindStruct(target.Field(index), properties, path); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		for key, value := range properties {
+			if strings.EqualFold(key, name) {
+				if err := bindValue(target.Field(index), value, joinPath(path, name)); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
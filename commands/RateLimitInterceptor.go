@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// RateLimitInterceptor limits command execution rate using a token bucket per command.
+// Each execution takes a token from the bucket, tokens are refilled with the configured rate
+// up to the bucket size. When the bucket is empty, the interceptor returns retryable
+// ConnectionError with "RATE_LIMIT_EXCEEDED" code, 429 (Too Many Requests) status
+// and RetryAfter set to the time until the next token is available.
+//
+// Configuration parameters:
+//
+//	rate_limit.rate: number of executions per second, 0 to disable (default: set in constructor)
+//	rate_limit.burst: maximum number of tokens in the bucket (default: equal to rate, but at least 1)
+//	commands.<name>.rate_limit.*: rate limit parameters for specific command
+//
+//	Example:
+//		interceptor := NewRateLimitInterceptor(100)
+//		interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+//			"commands.send_email.rate_limit.rate", 1,
+//			"commands.send_email.rate_limit.burst", 5,
+//		))
+//		commandSet.AddInterceptor(interceptor)
+type RateLimitInterceptor struct {
+	interceptorBase
+	rate    float64
+	mtx     sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewRateLimitInterceptor creates a new rate limit interceptor.
+//	Parameters: rate float64 a default number of executions per second, 0 to disable
+//	Returns: *RateLimitInterceptor
+func NewRateLimitInterceptor(rate float64) *RateLimitInterceptor {
+	return &RateLimitInterceptor{
+		rate:    rate,
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+// Execute executes the command if the rate limit is not exceeded.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- command: ICommand the next command in the call chain
+//		- args: *run.Parameters the parameters (arguments) to pass to the command
+//	Returns: (any, error) the execution result
+func (c *RateLimitInterceptor) Execute(ctx context.Context, correlationId string, command ICommand,
+	args *run.Parameters) (any, error) {
+
+	name := command.Name()
+	if wait, ok := c.take(name); !ok {
+		return nil, errors.NewConnectionError(
+			correlationId,
+			"RATE_LIMIT_EXCEEDED",
+			"Rate limit for command "+name+" exceeded",
+		).WithStatus(429).
+			WithDetails("command", name).
+			WithRetryable(true).
+			WithRetryAfter(wait)
+	}
+
+	return command.Execute(ctx, correlationId, args)
+}
+
+// take takes a token from the command bucket.
+// It returns false and time in milliseconds until the next token when the bucket is empty.
+func (c *RateLimitInterceptor) take(name string) (int64, bool) {
+	config := c.commandConfig(name)
+	rate := config.GetAsDoubleWithDefault("rate_limit.rate", c.rate)
+	if rate <= 0 {
+		return 0, true
+	}
+	burst := config.GetAsDoubleWithDefault("rate_limit.burst", math.Max(rate, 1))
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	bucket, ok := c.buckets[name]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, updated: now}
+		c.buckets[name] = bucket
+	}
+
+	bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*rate)
+	bucket.updated = now
+
+	if bucket.tokens < 1 {
+		wait := int64(math.Ceil((1 - bucket.tokens) / rate * 1000))
+		return wait, false
+	}
+
+	bucket.tokens--
+	return 0, true
+}
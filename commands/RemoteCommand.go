@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+// RemoteCommand is a command that is executed by a remote HttpCommandHandler.
+// Arguments are sent as a JSON object by "POST <url>/<command>". Results are returned
+// as decoded JSON values. Errors are restored from ErrorDescription returned by the handler.
+//
+// When a schema is set, arguments are validated locally before the call.
+//	see HttpCommandHandler
+//	Example:
+//		command := NewRemoteCommand("get_order", "http://localhost:8080/v1/orders")
+//		commandSet.AddCommand(command)
+//
+//		result, err := command.Execute(ctx, "123", run.NewParametersFromTuples("id", "1"))
+type RemoteCommand struct {
+	name   string
+	url    string
+	schema validate.ISchema
+	client *http.Client
+}
+
+// NewRemoteCommand creates a new remote command.
+//	Parameters:
+//		- name: string a command name
+//		- url: string a base url of the remote handler, for instance "http://localhost:8080/v1/orders"
+//	Returns: *RemoteCommand
+func NewRemoteCommand(name string, url string) *RemoteCommand {
+	if name == "" {
+		panic("Name cannot be empty")
+	}
+	if url == "" {
+		panic("Url cannot be empty")
+	}
+
+	return &RemoteCommand{
+		name:   name,
+		url:    strings.TrimRight(url, "/"),
+		client: http.DefaultClient,
+	}
+}
+
+// WithSchema sets a schema to validate arguments before the call.
+//	Parameters: schema validate.ISchema a validation schema
+//	Returns: *RemoteCommand
+func (c *RemoteCommand) WithSchema(schema validate.ISchema) *RemoteCommand {
+	c.schema = schema
+	return c
+}
+
+// WithHttpClient sets a HTTP client to call the remote handler.
+//	Parameters: client *http.Client a HTTP client
+//	Returns: *RemoteCommand
+func (c *RemoteCommand) WithHttpClient(client *http.Client) *RemoteCommand {
+	if client == nil {
+		panic("Client cannot be nil")
+	}
+	c.client = client
+	return c
+}
+
+// Name gets the command name.
+//	Returns: string the name of this command.
+func (c *RemoteCommand) Name() string {
+	return c.name
+}
+
+// GetSchema gets the schema to validate arguments.
+//	Returns: validate.ISchema the validation schema or nil if it is not set
+func (c *RemoteCommand) GetSchema() validate.ISchema {
+	return c.schema
+}
+
+// Validate validates the command arguments with the schema if it is set.
+// Otherwise arguments are validated by the remote handler.
+//	Parameters: args: *run.Parameters the parameters (arguments) to validate.
+//	Returns: []*validate.ValidationResult an array of ValidationResults
+func (c *RemoteCommand) Validate(args *run.Parameters) []*validate.ValidationResult {
+	if c.schema != nil {
+		return c.schema.Validate(args)
+	}
+	return []*validate.ValidationResult{}
+}
+
+// Execute calls the command on the remote handler.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- args: *run.Parameters the parameters (arguments) to pass to the command
+//	Returns: (any, error) the decoded JSON result or an error restored from ErrorDescription
+func (c *RemoteCommand) Execute(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+	var values any = map[string]any{}
+	if args != nil {
+		values = args.Value()
+	}
+	body, err := json.Marshal(values)
+	if err != nil {
+		return nil, errors.NewBadRequestError(correlationId, "INVALID_ARGS", "Failed to serialize command arguments").
+			WithDetails("command", c.name).
+			WithCause(err)
+	}
+
+	address := c.url + "/" + url.PathEscape(c.name)
+	if correlationId != "" {
+		address += "?correlation_id=" + url.QueryEscape(correlationId)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, address, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.NewConfigError(correlationId, "INVALID_URL", "Invalid command url "+address).
+			WithDetails("url", address).
+			WithCause(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, errors.NewConnectionError(correlationId, "CONNECT_FAILED", "Failed to call remote command "+c.name).
+			WithStatus(http.StatusServiceUnavailable).
+			WithDetails("command", c.name).
+			WithDetails("url", address).
+			WithCause(err)
+	}
+	defer response.Body.Close()
+
+	body, err = io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.NewConnectionError(correlationId, "RESPONSE_READ_FAILED",
+			"Failed to read response of remote command "+c.name).
+			WithStatus(http.StatusServiceUnavailable).
+			WithDetails("command", c.name).
+			WithCause(err)
+	}
+
+	if response.StatusCode >= 400 {
+		description := &errors.ErrorDescription{}
+		if err = json.Unmarshal(body, description); err != nil || description.Code == "" {
+			return nil, errors.ErrorStatusMapping.CreateFromHttpStatus(response.StatusCode, correlationId,
+				"REMOTE_ERROR", strings.TrimSpace(string(body))).
+				WithDetails("command", c.name)
+		}
+		return nil, errors.ApplicationErrorFactory.Create(description)
+	}
+
+	if response.StatusCode == http.StatusNoContent || len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	var result any
+	if err = json.Unmarshal(body, &result); err != nil {
+		return nil, errors.NewInvocationError(correlationId, "INVALID_RESPONSE", "Remote command "+c.name+" returned invalid JSON").
+			WithDetails("command", c.name).
+			WithCause(err)
+	}
+	return result, nil
+}
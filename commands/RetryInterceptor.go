@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// RetryInterceptor retries commands that failed with retryable errors.
+// Delays between attempts grow exponentially from the initial delay up to the maximum delay.
+// When an error defines RetryAfter, it is used instead of the calculated delay.
+//	see errors.IsRetryable
+//	see errors.GetRetryAfter
+//
+// Configuration parameters:
+//
+//	retry.attempts: maximum number of attempts including the first one (default: 3)
+//	retry.initial_delay: delay before the second attempt in milliseconds (default: 100)
+//	retry.max_delay: maximum delay between attempts in milliseconds (default: 10000)
+//	commands.<name>.retry.*: retry parameters for specific command
+//
+//	Example:
+//		interceptor := NewRetryInterceptor()
+//		interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+//			"retry.attempts", 5,
+//			"commands.create_order.retry.attempts", 1,
+//		))
+//		commandSet.AddInterceptor(interceptor)
+type RetryInterceptor struct {
+	interceptorBase
+}
+
+// NewRetryInterceptor creates a new retry interceptor.
+//	Returns: *RetryInterceptor
+func NewRetryInterceptor() *RetryInterceptor {
+	return &RetryInterceptor{}
+}
+
+// Execute executes the command and retries it on retryable errors.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- command: ICommand the next command in the call chain
+//		- args: *run.Parameters the parameters (arguments) to pass to the command
+//	Returns: (any, error) the result of the last attempt
+func (c *RetryInterceptor) Execute(ctx context.Context, correlationId string, command ICommand,
+	args *run.Parameters) (any, error) {
+
+	config := c.commandConfig(command.Name())
+	attempts := config.GetAsIntegerWithDefault("retry.attempts", 3)
+	delay := config.GetAsLongWithDefault("retry.initial_delay", 100)
+	maxDelay := config.GetAsLongWithDefault("retry.max_delay", 10000)
+
+	for attempt := 1; ; attempt++ {
+		result, err := command.Execute(ctx, correlationId, args)
+		if err == nil || attempt >= attempts || !errors.IsRetryable(err) {
+			return result, err
+		}
+
+		wait := delay
+		if retryAfter, ok := errors.GetRetryAfter(err); ok {
+			wait = retryAfter
+		}
+		if wait > maxDelay {
+			wait = maxDelay
+		}
+
+		select {
+		case <-time.After(time.Duration(wait) * time.Millisecond):
+		case <-ctx.Done():
+			return result, err
+		}
+
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
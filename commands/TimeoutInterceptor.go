@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// TimeoutInterceptor limits command execution time.
+// The command receives a context with deadline. When the deadline is exceeded,
+// the interceptor returns ConnectionError with "TIMEOUT" code without waiting
+// for commands that do not respect the context.
+//
+// Configuration parameters:
+//
+//	timeout: timeout in milliseconds, 0 to disable (default: set in constructor)
+//	commands.<name>.timeout: timeout for specific command
+//
+//	Example:
+//		interceptor := NewTimeoutInterceptor(5000)
+//		interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+//			"commands.get_data.timeout", 1000,
+//		))
+//		commandSet.AddInterceptor(interceptor)
+type TimeoutInterceptor struct {
+	interceptorBase
+	timeout int64
+}
+
+// NewTimeoutInterceptor creates a new timeout interceptor.
+//	Parameters: timeout int64 a default timeout in milliseconds, 0 to disable
+//	Returns: *TimeoutInterceptor
+func NewTimeoutInterceptor(timeout int64) *TimeoutInterceptor {
+	return &TimeoutInterceptor{
+		timeout: timeout,
+	}
+}
+
+// GetTimeout gets the timeout for the command.
+//	Parameters: name string a command name
+//	Returns: int64 the timeout in milliseconds
+func (c *TimeoutInterceptor) GetTimeout(name string) int64 {
+	return c.commandConfig(name).GetAsLongWithDefault("timeout", c.timeout)
+}
+
+// Execute executes the command with timeout.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- command: ICommand the next command in the call chain
+//		- args: *run.Parameters the parameters (arguments) to pass to the command
+//	Returns: (any, error) the execution result
+func (c *TimeoutInterceptor) Execute(ctx context.Context, correlationId string, command ICommand,
+	args *run.Parameters) (any, error) {
+
+	timeout := c.GetTimeout(command.Name())
+	if timeout <= 0 {
+		return command.Execute(ctx, correlationId, args)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+	defer cancel()
+
+	type executeResult struct {
+		result any
+		err    error
+	}
+	done := make(chan executeResult, 1)
+
+	go func() {
+		result, err := command.Execute(ctx, correlationId, args)
+		done <- executeResult{result: result, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.result, r.err
+	case <-ctx.Done():
+		return nil, errors.ErrorTranslator.Translate(correlationId, ctx.Err()).
+			WithDetails("command", command.Name()).
+			WithDetails("timeout", timeout)
+	}
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+)
+
+// CommandOutcomeHandler is a callback that receives execution time and outcome of a command.
+// The error is nil when the command succeeded.
+type CommandOutcomeHandler func(ctx context.Context, correlationId string, command string, duration time.Duration, err error)
+
+// TimingInterceptor measures command execution time and reports it with the outcome
+// to a callback. It can be used to collect metrics or write logs.
+//
+// Configuration parameters:
+//
+//	timing.enabled: true to report executions (default: true)
+//	commands.<name>.timing.enabled: true to report executions of specific command
+//
+//	Example:
+//		interceptor := NewTimingInterceptor(func(ctx context.Context, correlationId string,
+//			command string, duration time.Duration, err error) {
+//			if err != nil {
+//				fmt.Printf("%s failed in %v: %v\n", command, duration, err)
+//			} else {
+//				fmt.Printf("%s succeeded in %v\n", command, duration)
+//			}
+//		})
+//		commandSet.AddInterceptor(interceptor)
+type TimingInterceptor struct {
+	interceptorBase
+	handler CommandOutcomeHandler
+}
+
+// NewTimingInterceptor creates a new timing interceptor.
+//	Parameters: handler CommandOutcomeHandler a callback to receive execution time and outcome
+//	Returns: *TimingInterceptor
+func NewTimingInterceptor(handler CommandOutcomeHandler) *TimingInterceptor {
+	if handler == nil {
+		panic("Handler cannot be nil")
+	}
+
+	return &TimingInterceptor{
+		handler: handler,
+	}
+}
+
+// Execute executes the command and reports its execution time and outcome.
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId: string (optional) transaction id to trace execution through call chain.
+//		- command: ICommand the next command in the call chain
+//		- args: *run.Parameters the parameters (arguments) to pass to the command
+//	Returns: (any, error) the execution result
+func (c *TimingInterceptor) Execute(ctx context.Context, correlationId string, command ICommand,
+	args *run.Parameters) (any, error) {
+
+	name := command.Name()
+	if !c.commandConfig(name).GetAsBooleanWithDefault("timing.enabled", true) {
+		return command.Execute(ctx, correlationId, args)
+	}
+
+	start := time.Now()
+	result, err := command.Execute(ctx, correlationId, args)
+	c.handler(ctx, correlationId, name, time.Since(start), err)
+	return result, err
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+)
+
+// TypedCommand is a command with strongly typed arguments and result.
+// Before execution it validates parameters using the schema and binds them into TArgs.
+// It implements ICommand, so it can be added to CommandSet and wrapped by interceptors as any other command.
+//	see BindParameters
+//	see validate.NewObjectSchemaFromType
+//	Example:
+//		type SumArgs struct {
+//			A float64 `json:"a"`
+//			B float64 `json:"b"`
+//		}
+//
+//		command := NewTypedCommand("sum", nil, func(ctx context.Context, correlationId string, args SumArgs) (float64, error) {
+//			return args.A + args.B, nil
+//		})
+//
+//		result, err := command.Execute(context.Background(), "123", run.NewParametersFromTuples("a", 2, "b", "2"))
+//		// result == 4.0
+type TypedCommand[TArgs any, TResult any] struct {
+	*Command
+	action func(ctx context.Context, correlationId string, args TArgs) (TResult, error)
+}
+
+// NewTypedCommand creates a new typed command.
+// When schema is nil and TArgs is a struct, the schema is derived from TArgs json tags.
+//	Parameters:
+//		- name: string - the command name.
+//		- schema: validate.ISchema the schema to validate command arguments, or nil to derive it from TArgs.
+//		- action: func(ctx context.Context, correlationId string, args TArgs) (TResult, error)
+//			the function to be executed by this command.
+//	Returns: *TypedCommand[TArgs, TResult]
+func NewTypedCommand[TArgs any, TResult any](name string, schema validate.ISchema,
+	action func(ctx context.Context, correlationId string, args TArgs) (TResult, error)) *TypedCommand[TArgs, TResult] {
+
+	if action == nil {
+		panic("Action cannot be nil")
+	}
+
+	if schema == nil {
+		var args TArgs
+		if objectSchema := validate.NewObjectSchemaFromType(&args); objectSchema != nil {
+			schema = objectSchema
+		}
+	}
+
+	c := &TypedCommand[TArgs, TResult]{
+		action: action,
+	}
+	c.Command = NewCommand(name, schema, c.execute)
+	return c
+}
+
+func (c *TypedCommand[TArgs, TResult]) execute(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+	typedArgs, err := BindParameters[TArgs](correlationId, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.action(ctx, correlationId, typedArgs)
+}
+
+// ExecuteTyped executes the command with already typed arguments.
+// It skips schema validation and binding of parameters.
+//	Parameters:
+//		- ctx context.Context.
+//		- correlationId: string - (optional) transaction id to trace execution through call chain.
+//		- args: TArgs - command arguments.
+//	Returns: (TResult, error)
+func (c *TypedCommand[TArgs, TResult]) ExecuteTyped(ctx context.Context, correlationId string, args TArgs) (TResult, error) {
+	return c.action(ctx, correlationId, args)
+}
@@ -0,0 +1,124 @@
+package errors
+
+// Errors that aggregate multiple failures, for instance when a batch of components
+// is opened, closed or executed and several of them fail.
+//
+// Aggregated errors are traversed by Is and As, and they are passed through the wire
+// in ErrorDescription.Errors.
+
+// NewAggregateError creates an error instance that aggregates multiple errors.
+// Until errors are added it belongs to Unknown category. When all added errors
+// are application errors of the same category, the aggregate takes their category and status.
+//	see ErrorCategory
+//	Parameters:
+//		- correlation_id string a unique transaction id to trace execution through call chain.
+//		- code string a unique error code.
+//		- message string a human-readable description of the error.
+//		- errs ...error errors to aggregate
+//	Returns: *ApplicationError
+func NewAggregateError(correlationId, code, message string, errs ...error) *ApplicationError {
+	e := captureStackTrace(&ApplicationError{
+		Category:      Unknown,
+		CorrelationId: correlationId,
+		Code:          code,
+		Message:       message,
+		Status:        500,
+		aggregate:     true,
+	})
+	for _, err := range errs {
+		e.AddError(err)
+	}
+	return e
+}
+
+// componentError is an aggregated error that keeps the name of the failed component.
+type componentError struct {
+	component string
+	err       error
+}
+
+func (c *componentError) Error() string {
+	return c.component + ": " + c.err.Error()
+}
+
+func (c *componentError) Unwrap() error {
+	return c.err
+}
+
+// AddError adds an error to the aggregate. Nil errors are ignored.
+// Only errors created by NewAggregateError take category and status from aggregated errors,
+// other errors keep their own category and status.
+//	Parameters: err error an error to add
+//	Returns: *ApplicationError
+func (e *ApplicationError) AddError(err error) *ApplicationError {
+	if err == nil {
+		return e
+	}
+	e.errors = append(e.errors, err)
+	if e.aggregate {
+		e.updateAggregateCategory()
+	}
+	return e
+}
+
+// AddComponentError adds an error raised by a specific component to the aggregate.
+// The component name is passed in details of the error description. Nil errors are ignored.
+//	Parameters:
+//		- component string a name of the failed component
+//		- err error an error to add
+//	Returns: *ApplicationError
+func (e *ApplicationError) AddComponentError(component string, err error) *ApplicationError {
+	if err == nil {
+		return e
+	}
+	return e.AddError(&componentError{component: component, err: err})
+}
+
+// Errors gets all aggregated errors.
+//	Returns: []error a list of aggregated errors
+func (e *ApplicationError) Errors() []error {
+	return e.errors
+}
+
+func (e *ApplicationError) updateAggregateCategory() {
+	category := ""
+	status := 0
+	for _, err := range e.errors {
+		var ex *ApplicationError
+		if !As(err, &ex) || (category != "" && category != ex.Category) {
+			e.Category = Unknown
+			e.Status = 500
+			return
+		}
+		category = ex.Category
+		status = ex.Status
+	}
+	e.Category = category
+	e.Status = status
+}
+
+// newAggregatedDescriptions creates descriptions for aggregated errors.
+//	Parameters: errs []error aggregated errors
+//	Returns: []*ErrorDescription
+func newAggregatedDescriptions(errs []error) []*ErrorDescription {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	result := make([]*ErrorDescription, 0, len(errs))
+	for _, err := range errs {
+		if ce, ok := err.(*componentError); ok {
+			description := NewErrorDescription(ce.err)
+			details := make(map[string]any, len(description.Details)+1)
+			for key, value := range description.Details {
+				details[key] = value
+			}
+			details["component"] = ce.component
+			description.Details = details
+			result = append(result, description)
+		} else {
+			result = append(result, NewErrorDescription(err))
+		}
+	}
+	return result
+}
@@ -1,5 +1,11 @@
 package errors
 
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+)
+
 // ApplicationError Defines a base class to defive various application exceptions.
 //
 // Most languages have own definition of base exception (error) types.
@@ -12,21 +18,33 @@ package errors
 // errors are returned throught the entire call chain and restored in their original (or close) type.
 //
 // Since number of potential exception types is endless,
-// PipServices toolkit supports only 12 standard categories of exceptions defined in ErrorCategory.
-// This ApplicationException class acts as a basis for all other 12 standard exception types.
+// PipServices toolkit supports only 13 standard categories of exceptions defined in ErrorCategory.
+// This ApplicationException class acts as a basis for all other 13 standard exception types.
 //
 // Most exceptions have just free-form message that describes occured error.
 // That may not be sufficient to create meaninful error descriptions.
 // The ApplicationException class proposes an extended error definition that has more standard fields:
 //
 //	message: is a human-readable error description
-//	category: one of 12 standard error categories of errors
+//	category: one of 13 standard error categories of errors
 //	status: numeric HTTP status code for REST invocations
 //	code: a unique error code, usually defined as "MY_ERROR_CODE"
 //	correlation_id: a unique transaction id to trace execution through a call chain
 //	details: map with error parameters that can help to recreate meaningful error description in other languages
 //	stack_trace: a stack trace
 //	cause: original error that is wrapped by this exception
+//	retryable: (optional) overrides the default retry classification of the error category
+//	retry_after: (optional) a hint in milliseconds when the failed call can be retried
+//
+//	The original cause is kept as an error value, so ApplicationError
+//	supports the standard Unwrap, Is and As error chain traversal.
+//	Two application errors are considered equal by Is when they have
+//	the same category and code.
+//
+//	Stack traces are captured automatically when errors are created or wrapped
+//	according to the mode set in StackTraceCapture. Use "%+v" format
+//	to print an error with its stack trace and causes.
+//
 //	ApplicationException class is not serializable.
 //		To pass errors through the wire it is converted into
 //		ErrorDescription object and restored on receiving end into identical exception type.
@@ -42,6 +60,12 @@ type ApplicationError struct {
 	CorrelationId string         `json:"correlation_id"`
 	StackTrace    string         `json:"stack_trace"`
 	Cause         string         `json:"cause"`
+	Retryable     *bool          `json:"retryable"`
+	RetryAfter    int64          `json:"retry_after"`
+
+	cause     error
+	errors    []error
+	aggregate bool
 }
 
 // Error return string error message
@@ -82,8 +106,12 @@ func (e *ApplicationError) WithDetails(key string, value any) *ApplicationError
 //	Parameters: cause error a cause error object
 //	Returns: *ApplicationError
 func (e *ApplicationError) WithCause(cause error) *ApplicationError {
+	if cause == nil {
+		return e
+	}
 	e.Cause = cause.Error()
-	return e
+	e.cause = cause
+	return captureStackTrace(e)
 }
 
 // WithCauseString add cause to ApplicationError
@@ -91,9 +119,114 @@ func (e *ApplicationError) WithCause(cause error) *ApplicationError {
 //	Returns: *ApplicationError
 func (e *ApplicationError) WithCauseString(cause string) *ApplicationError {
 	e.Cause = cause
+	e.cause = nil
 	return e
 }
 
+// WithRetryable overrides the default retry classification of the error category
+//	Parameters: retryable bool true if the failed call is safe to retry
+//	Returns: *ApplicationError
+func (e *ApplicationError) WithRetryable(retryable bool) *ApplicationError {
+	e.Retryable = &retryable
+	return e
+}
+
+// WithRetryAfter add a hint when the failed call can be retried
+//	Parameters: retryAfter int64 a delay in milliseconds
+//	Returns: *ApplicationError
+func (e *ApplicationError) WithRetryAfter(retryAfter int64) *ApplicationError {
+	e.RetryAfter = retryAfter
+	return e
+}
+
+// IsRetryable checks if the failed call is safe to retry.
+// If retry classification is not set explicitly, the default for the error category
+// is taken from ErrorCategoryRegistry.
+//	Returns: bool true if the call can be retried
+func (e *ApplicationError) IsRetryable() bool {
+	if e.Retryable != nil {
+		return *e.Retryable
+	}
+	return ErrorCategoryRegistry.IsRetryable(e.Category)
+}
+
+// Format formats the error according to the fmt.Formatter interface.
+//	%s, %v - the error message
+//	%q - the quoted error message
+//	%+v - the error message with category, code, stack trace and the chain of causes
+func (e *ApplicationError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%s [category=%s code=%s status=%d", e.Message, e.Category, e.Code, e.Status)
+			if e.CorrelationId != "" {
+				fmt.Fprintf(s, " correlation_id=%s", e.CorrelationId)
+			}
+			io.WriteString(s, "]")
+			if e.StackTrace != "" {
+				io.WriteString(s, "\n")
+				io.WriteString(s, e.StackTrace)
+			}
+			if e.cause != nil {
+				fmt.Fprintf(s, "\nCaused by: %+v", e.cause)
+			} else if e.Cause != "" {
+				io.WriteString(s, "\nCaused by: ")
+				io.WriteString(s, e.Cause)
+			}
+			for i, err := range e.errors {
+				fmt.Fprintf(s, "\nError %d: %+v", i+1, err)
+			}
+			return
+		}
+		io.WriteString(s, e.Error())
+	case 's':
+		io.WriteString(s, e.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Error())
+	}
+}
+
+// Unwrap returns the original error wrapped by this ApplicationError.
+// Errors restored from ErrorDescription carry only a cause string and return nil.
+//	Returns: error the wrapped error or nil
+func (e *ApplicationError) Unwrap() error {
+	return e.cause
+}
+
+// Is checks if this ApplicationError matches the target error.
+// The target matches when it is an ApplicationError with the same category and code.
+// Empty category or code in the target are treated as wildcards.
+// For aggregate errors all aggregated errors are checked as well.
+//	Parameters: target error an error to compare with
+//	Returns: bool true if errors match and false otherwise
+func (e *ApplicationError) Is(target error) bool {
+	if t, ok := target.(*ApplicationError); ok && t != nil && (t.Category != "" || t.Code != "") &&
+		(t.Category == "" || t.Category == e.Category) &&
+		(t.Code == "" || t.Code == e.Code) {
+		return true
+	}
+
+	for _, err := range e.errors {
+		if Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error among aggregated errors that matches target.
+// The primary cause is handled by Unwrap.
+//	Parameters: target any a non-nil pointer to a variable of error type
+//	Returns: bool true if a match is found and false otherwise
+func (e *ApplicationError) As(target any) bool {
+	for _, err := range e.errors {
+		if As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
 // WithCorrelationId add Correlation Id to ApplicationError
 //	Parameters: correlationId string a correlation string
 //	Returns: *ApplicationError
@@ -114,7 +247,9 @@ func (e *ApplicationError) Wrap(err error) *ApplicationError {
 	return e
 }
 
-// WrapError wrap error by ApplicationError struct and sets message
+// WrapError wrap error by ApplicationError struct and sets message.
+// When translation is enabled in ErrorTranslator, the category, code and status
+// are taken from the matching translation.
 //	Parameters:
 //		- err error an error what neet to wrap
 //		- message string error message
@@ -124,6 +259,15 @@ func WrapError(err error, message string) *ApplicationError {
 		return e
 	}
 
+	if ErrorTranslator.IsTranslateInWrapError() {
+		if e, ok := ErrorTranslator.TryTranslate("", err); ok {
+			if message != "" {
+				e.Message = message
+			}
+			return e
+		}
+	}
+
 	return NewError(message).WithCause(err)
 }
 
@@ -134,5 +278,33 @@ func NewError(message string) *ApplicationError {
 	if message == "" {
 		message = "Unknown error"
 	}
-	return &ApplicationError{Code: "UNKNOWN", Message: message, Status: 500}
+	return captureStackTrace(&ApplicationError{Code: "UNKNOWN", Message: message, Status: 500})
+}
+
+// Is reports whether any error in err's chain matches target.
+// It is a shortcut to the standard errors.Is, since this package shadows its name.
+//	Parameters:
+//		- err error an error to check
+//		- target error an error to search for
+//	Returns: bool true if a match is found and false otherwise
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// As finds the first error in err's chain that matches target, and if so, sets target to that error value.
+// It is a shortcut to the standard errors.As, since this package shadows its name.
+//	Parameters:
+//		- err error an error to check
+//		- target any a non-nil pointer to a variable of error type
+//	Returns: bool true if a match is found and false otherwise
+func As(err error, target any) bool {
+	return stderrors.As(err, target)
+}
+
+// Unwrap returns the result of calling the Unwrap method on err, if it has one.
+// It is a shortcut to the standard errors.Unwrap, since this package shadows its name.
+//	Parameters: err error an error to unwrap
+//	Returns: error the wrapped error or nil
+func Unwrap(err error) error {
+	return stderrors.Unwrap(err)
 }
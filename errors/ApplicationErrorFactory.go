@@ -17,6 +17,9 @@ func (c *_TApplicationErrorFactory) Create(description *ErrorDescription) *Appli
 
 // NewErrorFromDescription Recreates ApplicationError object from description.
 // It tries to restore original exception type using type or error category fields.
+// Error types are resolved through categories registered in ErrorCategoryRegistry.
+// A non-zero status in the description overrides the default status of the category,
+// so errors raised with a specific status keep it after a round trip.
 //	Parameters: description: ErrorDescription a serialized error description received as a result of remote call
 //	Returns: *ApplicationError
 func NewErrorFromDescription(description *ErrorDescription) *ApplicationError {
@@ -30,54 +33,28 @@ func NewErrorFromDescription(description *ErrorDescription) *ApplicationError {
 	message := description.Message
 	correlationId := description.CorrelationId
 
-	// Create well-known exception type based on error category
-	switch category {
-	case Unknown:
-		err = NewUnknownError(correlationId, code, message)
-		break
-	case Internal:
-		err = NewInternalError(correlationId, code, message)
-		break
-	case Misconfiguration:
-		err = NewConfigError(correlationId, code, message)
-		break
-	case NoResponse:
-		err = NewConnectionError(correlationId, code, message)
-		break
-	case FailedInvocation:
-		err = NewInvocationError(correlationId, code, message)
-		break
-	case FileError:
-		err = NewFileError(correlationId, code, message)
-		break
-	case BadRequest:
-		err = NewBadRequestError(correlationId, code, message)
-		break
-	case Unauthorized:
-		err = NewUnauthorizedError(correlationId, code, message)
-		break
-	case Conflict:
-		err = NewConflictError(correlationId, code, message)
-		break
-	case NotFound:
-		err = NewNotFoundError(correlationId, code, message)
-		break
-	case InvalidState:
-		err = NewInvalidStateError(correlationId, code, message)
-		break
-	case Unsupported:
-		err = NewUnsupportedError(correlationId, code, message)
-		break
-	default:
+	// Create well-known exception type based on registered error category
+	if ErrorCategoryRegistry.Has(category) {
+		err = ErrorCategoryRegistry.Create(category, correlationId, code, message)
+	} else {
 		err = NewUnknownError(correlationId, code, message)
 		err.Category = category
+	}
+	if description.Status != 0 {
 		err.Status = description.Status
 	}
 
-	// Fill error with details
+	// Fill error with details. The stack trace captured on the remote side replaces the local one
 	err.Details = description.Details
 	err.Cause = description.Cause
 	err.StackTrace = description.StackTrace
+	err.Retryable = description.Retryable
+	err.RetryAfter = description.RetryAfter
+	for _, d := range description.Errors {
+		if e := NewErrorFromDescription(d); e != nil {
+			err.errors = append(err.errors, e)
+		}
+	}
 
 	return err
 }
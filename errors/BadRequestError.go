@@ -12,11 +12,11 @@ package errors
 //		- message string a human-readable description of the error.
 //	Returns: *ApplicationError
 func NewBadRequestError(correlationId, code, message string) *ApplicationError {
-	return &ApplicationError{
+	return captureStackTrace(&ApplicationError{
 		Category:      BadRequest,
 		CorrelationId: correlationId,
 		Code:          code,
 		Message:       message,
 		Status:        400,
-	}
+	})
 }
@@ -10,11 +10,11 @@ package errors
 //		- message string a human-readable description of the error.
 //	Returns: *ApplicationError
 func NewConfigError(correlationId, code, message string) *ApplicationError {
-	return &ApplicationError{
+	return captureStackTrace(&ApplicationError{
 		Category:      Misconfiguration,
 		CorrelationId: correlationId,
 		Code:          code,
 		Message:       message,
 		Status:        500,
-	}
+	})
 }
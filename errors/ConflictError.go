@@ -11,11 +11,11 @@ package errors
 //		- message string a human-readable description of the error.
 //	Returns: *ApplicationError
 func NewConflictError(correlationId, code, message string) *ApplicationError {
-	return &ApplicationError{
+	return captureStackTrace(&ApplicationError{
 		Category:      Conflict,
 		CorrelationId: correlationId,
 		Code:          code,
 		Message:       message,
 		Status:        409,
-	}
+	})
 }
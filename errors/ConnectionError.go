@@ -11,11 +11,11 @@ package errors
 //		- message string a human-readable description of the error.
 //	Returns: *ApplicationError
 func NewConnectionError(correlationId, code, message string) *ApplicationError {
-	return &ApplicationError{
+	return captureStackTrace(&ApplicationError{
 		Category:      NoResponse,
 		CorrelationId: correlationId,
 		Code:          code,
 		Message:       message,
 		Status:        500,
-	}
+	})
 }
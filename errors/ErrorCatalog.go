@@ -0,0 +1,242 @@
+package errors
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrorCodeDefinition describes a registered error code.
+//
+//	code - A unique error code
+//	category - Standard error category
+//	status - HTTP status code associated with this error code
+//	message - A default message template, for instance "Command {{command}} not found"
+//	translations - Message templates per locale, for instance "de" or "pt-BR"
+type ErrorCodeDefinition struct {
+	Code         string            `json:"code"`
+	Category     string            `json:"category"`
+	Status       int               `json:"status"`
+	Message      string            `json:"message"`
+	Translations map[string]string `json:"translations,omitempty"`
+}
+
+// ErrorCatalog is a catalog of error codes with their categories, statuses and message templates.
+// Message templates interpolate values from error details using {{key}} placeholders
+// and can be translated for different locales.
+// The catalog is used to render ErrorDescription for a requested language and to publish
+// a machine-readable list of all registered error codes.
+//	see ErrorCodeDefinition
+//	see ErrorDescriptionFactory
+//	Example:
+//		errors.ErrorCatalog.Register("CMD_NOT_FOUND", errors.BadRequest, 400, "Command {{command}} not found")
+//		errors.ErrorCatalog.AddTranslation("CMD_NOT_FOUND", "de", "Befehl {{command}} nicht gefunden")
+//
+//		err := errors.ErrorCatalog.Create("123", "CMD_NOT_FOUND", map[string]any{"command": "get_data"})
+//		// err.Message == "Command get_data not found"
+//
+//		description := errors.ErrorDescriptionFactory.CreateLocalized(err, "de-DE")
+//		// description.Message == "Befehl get_data nicht gefunden"
+var ErrorCatalog = &_TErrorCatalog{
+	codes: map[string]*ErrorCodeDefinition{},
+}
+
+type _TErrorCatalog struct {
+	mtx   sync.RWMutex
+	codes map[string]*ErrorCodeDefinition
+}
+
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([\w.\-]+)\s*\}\}`)
+
+// Register registers or replaces an error code in the catalog.
+//	Parameters:
+//		- code string a unique error code
+//		- category string an error category
+//		- status int HTTP status code. If it is 0, the default status of the category is used.
+//		- message string a default message template
+func (c *_TErrorCatalog) Register(code string, category string, status int, message string) {
+	if code == "" {
+		panic("Code cannot be empty")
+	}
+	if status == 0 {
+		status, _ = ErrorCategoryRegistry.GetStatus(category)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.codes[code] = &ErrorCodeDefinition{
+		Code:         code,
+		Category:     category,
+		Status:       status,
+		Message:      message,
+		Translations: map[string]string{},
+	}
+}
+
+// AddTranslation adds a message template for a specific locale.
+// The call has no effect if the code is not registered.
+//	Parameters:
+//		- code string a registered error code
+//		- locale string a locale, for instance "de" or "pt-BR"
+//		- message string a translated message template
+func (c *_TErrorCatalog) AddTranslation(code string, locale string, message string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if definition, ok := c.codes[code]; ok {
+		definition.Translations[normalizeLocale(locale)] = message
+	}
+}
+
+// Get gets a copy of the error code definition.
+//	Parameters: code string an error code
+//	Returns: *ErrorCodeDefinition the definition and bool true if the code is registered
+func (c *_TErrorCatalog) Get(code string) (*ErrorCodeDefinition, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	definition, ok := c.codes[code]
+	if !ok {
+		return nil, false
+	}
+	return definition.clone(), true
+}
+
+// Codes gets copies of all registered error code definitions sorted by code.
+// The result can be serialized into JSON to document error codes of an API.
+//	Returns: []*ErrorCodeDefinition
+func (c *_TErrorCatalog) Codes() []*ErrorCodeDefinition {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	result := make([]*ErrorCodeDefinition, 0, len(c.codes))
+	for _, definition := range c.codes {
+		result = append(result, definition.clone())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+	return result
+}
+
+// FormatMessage renders a message for the error code in the requested locale.
+// Locales fall back from "pt-BR" to "pt" and then to the default template.
+//	Parameters:
+//		- code string a registered error code
+//		- locale string (optional) a requested locale
+//		- details map[string]any values for template placeholders
+//	Returns: string the rendered message and bool true if the code is registered
+func (c *_TErrorCatalog) FormatMessage(code string, locale string, details map[string]any) (string, bool) {
+	template, ok := c.findTemplate(code, locale, true)
+	if !ok {
+		return "", false
+	}
+	return RenderMessageTemplate(template, details), true
+}
+
+// Create creates an error for a registered error code. The error type is resolved by
+// the code category and the message is rendered from the default template.
+// Unregistered codes are created as UnknownError.
+//	Parameters:
+//		- correlationId string a unique transaction id to trace execution through call chain.
+//		- code string a registered error code
+//		- details map[string]any error details used to render the message
+//	Returns: *ApplicationError
+func (c *_TErrorCatalog) Create(correlationId string, code string, details map[string]any) *ApplicationError {
+	definition, ok := c.Get(code)
+	if !ok {
+		err := NewUnknownError(correlationId, code, code)
+		err.Details = details
+		return err
+	}
+
+	message := RenderMessageTemplate(definition.Message, details)
+	err := ErrorCategoryRegistry.Create(definition.Category, correlationId, code, message)
+	err.Status = definition.Status
+	err.Details = details
+	return err
+}
+
+// Localize renders the message of the error description in the requested locale.
+// The description is not changed when its code is not registered or it has no translation for the locale.
+//	Parameters:
+//		- description *ErrorDescription an error description
+//		- locale string a requested locale
+//	Returns: *ErrorDescription a localized copy of the description
+func (c *_TErrorCatalog) Localize(description *ErrorDescription, locale string) *ErrorDescription {
+	if description == nil {
+		return nil
+	}
+
+	result := *description
+	if template, ok := c.findTemplate(description.Code, locale, false); ok {
+		result.Message = RenderMessageTemplate(template, description.Details)
+	}
+
+	if len(description.Errors) > 0 {
+		result.Errors = make([]*ErrorDescription, len(description.Errors))
+		for i, d := range description.Errors {
+			result.Errors[i] = c.Localize(d, locale)
+		}
+	}
+	return &result
+}
+
+func (c *_TErrorCatalog) findTemplate(code string, locale string, useDefault bool) (string, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	definition, ok := c.codes[code]
+	if !ok {
+		return "", false
+	}
+
+	locale = normalizeLocale(locale)
+	for locale != "" {
+		if template, ok := definition.Translations[locale]; ok {
+			return template, true
+		}
+		index := strings.LastIndex(locale, "-")
+		if index < 0 {
+			break
+		}
+		locale = locale[:index]
+	}
+
+	if useDefault {
+		return definition.Message, true
+	}
+	return "", false
+}
+
+func (d *ErrorCodeDefinition) clone() *ErrorCodeDefinition {
+	result := *d
+	result.Translations = make(map[string]string, len(d.Translations))
+	for locale, message := range d.Translations {
+		result.Translations[locale] = message
+	}
+	return &result
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// RenderMessageTemplate interpolates {{key}} placeholders in the message template with values from details.
+// Placeholders without values are left unchanged.
+//	Parameters:
+//		- template string a message template
+//		- details map[string]any values for placeholders
+//	Returns: string the rendered message
+func RenderMessageTemplate(template string, details map[string]any) string {
+	return templatePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		key := templatePlaceholder.FindStringSubmatch(placeholder)[1]
+		if value, ok := details[key]; ok {
+			return fmt.Sprintf("%v", value)
+		}
+		return placeholder
+	})
+}
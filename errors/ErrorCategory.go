@@ -25,9 +25,14 @@ package errors
 //
 //	Unauthorized - Access errors caused by missing user identity (authentication error) or incorrect security permissions (authorization error).
 //
+//	Forbidden - Access errors caused by insufficient security permissions of an authenticated caller.
+//	Unlike Unauthorized it is reported with 403 status and PermissionDenied gRPC code.
+//
 //	Unknown - Unknown or unexpected errors.
 //
 //	Unsupported - Errors caused by calls to unsupported or not yet implemented functionality.
+//
+// Additional domain-specific categories can be registered in ErrorCategoryRegistry.
 const (
 	Unknown          = "Unknown"
 	Internal         = "Internal"
@@ -38,6 +43,7 @@ const (
 	FileError        = "FileError"
 	BadRequest       = "BadRequest"
 	Unauthorized     = "Unauthorized"
+	Forbidden        = "Forbidden"
 	NotFound         = "NotFound"
 	Conflict         = "Conflict"
 	Unsupported      = "Unsupported"
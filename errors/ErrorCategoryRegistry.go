@@ -0,0 +1,179 @@
+package errors
+
+import "sync"
+
+// ErrorCategoryRegistry is a registry of error categories used to recreate errors of the right type.
+// Each category is registered with its default HTTP status, an optional constructor
+// and a default retry classification.
+// The registry is initialized with the standard categories defined in ErrorCategory,
+// and it can be extended with domain-specific categories that survive a round trip through ErrorDescription.
+//	see ErrorCategory
+//	see ApplicationErrorFactory
+//	Example:
+//		errors.ErrorCategoryRegistry.Register("RateLimited", 429, nil)
+//
+//		err := errors.ErrorCategoryRegistry.Create("RateLimited", "123", "TOO_MANY_REQUESTS", "Too many requests")
+//		description := errors.ErrorDescriptionFactory.Create(err)
+//		restored := errors.ApplicationErrorFactory.Create(description) // restored.Category == "RateLimited"
+var ErrorCategoryRegistry = newErrorCategoryRegistry()
+
+// ErrorConstructor is a function that creates an error of a specific category.
+type ErrorConstructor func(correlationId, code, message string) *ApplicationError
+
+type errorCategoryEntry struct {
+	status      int
+	constructor ErrorConstructor
+	retryable   bool
+}
+
+type _TErrorCategoryRegistry struct {
+	mtx        sync.RWMutex
+	categories map[string]errorCategoryEntry
+}
+
+func newErrorCategoryRegistry() *_TErrorCategoryRegistry {
+	c := &_TErrorCategoryRegistry{
+		categories: map[string]errorCategoryEntry{},
+	}
+
+	c.Register(Unknown, 500, NewUnknownError)
+	c.Register(Internal, 500, NewInternalError)
+	c.Register(Misconfiguration, 500, NewConfigError)
+	c.Register(NoResponse, 500, NewConnectionError)
+	c.Register(FailedInvocation, 500, NewInvocationError)
+	c.Register(FileError, 500, NewFileError)
+	c.Register(BadRequest, 400, NewBadRequestError)
+	c.Register(Unauthorized, 401, NewUnauthorizedError)
+	c.Register(Forbidden, 403, NewForbiddenError)
+	c.Register(Conflict, 409, NewConflictError)
+	c.Register(NotFound, 404, NewNotFoundError)
+	c.Register(InvalidState, 500, NewInvalidStateError)
+	c.Register(Unsupported, 500, NewUnsupportedError)
+
+	c.SetRetryable(NoResponse, true)
+	c.SetRetryable(FailedInvocation, true)
+
+	return c
+}
+
+// Register registers or replaces an error category.
+// Replacing a category keeps its retry classification set by SetRetryable.
+//	Parameters:
+//		- category string a name of the error category
+//		- status int a default HTTP status code for errors of this category
+//		- constructor ErrorConstructor (optional) a function to create errors of this category.
+//			If it is nil, a generic ApplicationError with the category and status is created.
+func (c *_TErrorCategoryRegistry) Register(category string, status int, constructor ErrorConstructor) {
+	if category == "" {
+		panic("Category cannot be empty")
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	entry := c.categories[category]
+	entry.status = status
+	entry.constructor = constructor
+	c.categories[category] = entry
+}
+
+// SetRetryable sets if errors of the category are safe to retry by default.
+// The standard NoResponse and FailedInvocation categories are retryable, others are not.
+//	Parameters:
+//		- category string a name of the error category
+//		- retryable bool true if errors of this category can be retried
+func (c *_TErrorCategoryRegistry) SetRetryable(category string, retryable bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if entry, ok := c.categories[category]; ok {
+		entry.retryable = retryable
+		c.categories[category] = entry
+	}
+}
+
+// IsRetryable checks if errors of the category are safe to retry by default.
+//	Parameters: category string a name of the error category
+//	Returns: bool true if errors of this category can be retried
+func (c *_TErrorCategoryRegistry) IsRetryable(category string) bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.categories[category].retryable
+}
+
+// Unregister removes an error category from the registry.
+//	Parameters: category string a name of the error category
+func (c *_TErrorCategoryRegistry) Unregister(category string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	delete(c.categories, category)
+}
+
+// Has checks if the error category is registered.
+//	Parameters: category string a name of the error category
+//	Returns: bool true if the category is registered and false otherwise
+func (c *_TErrorCategoryRegistry) Has(category string) bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	_, ok := c.categories[category]
+	return ok
+}
+
+// GetStatus gets the default HTTP status code for the error category.
+//	Parameters: category string a name of the error category
+//	Returns: int the status code and bool true if the category is registered
+func (c *_TErrorCategoryRegistry) GetStatus(category string) (int, bool) {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	entry, ok := c.categories[category]
+	return entry.status, ok
+}
+
+// Categories gets names of all registered error categories.
+//	Returns: []string a list of category names
+func (c *_TErrorCategoryRegistry) Categories() []string {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	result := make([]string, 0, len(c.categories))
+	for category := range c.categories {
+		result = append(result, category)
+	}
+	return result
+}
+
+// Create creates an error of the specified category.
+// Unregistered categories are created as UnknownError with the category name preserved.
+//	Parameters:
+//		- category string a name of the error category
+//		- correlationId string a unique transaction id to trace execution through call chain.
+//		- code string a unique error code.
+//		- message string a human-readable description of the error.
+//	Returns: *ApplicationError
+func (c *_TErrorCategoryRegistry) Create(category, correlationId, code, message string) *ApplicationError {
+	c.mtx.RLock()
+	entry, ok := c.categories[category]
+	c.mtx.RUnlock()
+
+	if !ok {
+		err := NewUnknownError(correlationId, code, message)
+		err.Category = category
+		return err
+	}
+
+	if entry.constructor != nil {
+		return entry.constructor(correlationId, code, message)
+	}
+
+	return captureStackTrace(&ApplicationError{
+		Category:      category,
+		CorrelationId: correlationId,
+		Code:          code,
+		Message:       message,
+		Status:        entry.status,
+	})
+}
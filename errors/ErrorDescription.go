@@ -13,14 +13,20 @@ package errors
 //	stack_trace - Stack trace of the exception
 //	status - HTTP status code associated with this error type
 //	type - Data type of the original error
+//	errors - (optional) Descriptions of errors aggregated by this error
+//	retryable - (optional) Explicit retry classification of the error
+//	retry_after - (optional) A hint in milliseconds when the failed call can be retried
 type ErrorDescription struct {
-	Type          string         `json:"type"`
-	Category      string         `json:"category"`
-	Status        int            `json:"status"`
-	Code          string         `json:"code"`
-	Message       string         `json:"message"`
-	Details       map[string]any `json:"details"`
-	CorrelationId string         `json:"correlation_id"`
-	Cause         string         `json:"cause"`
-	StackTrace    string         `json:"stack_trace"`
+	Type          string              `json:"type"`
+	Category      string              `json:"category"`
+	Status        int                 `json:"status"`
+	Code          string              `json:"code"`
+	Message       string              `json:"message"`
+	Details       map[string]any      `json:"details"`
+	CorrelationId string              `json:"correlation_id"`
+	Cause         string              `json:"cause"`
+	StackTrace    string              `json:"stack_trace"`
+	Retryable     *bool               `json:"retryable,omitempty"`
+	RetryAfter    int64               `json:"retry_after,omitempty"`
+	Errors        []*ErrorDescription `json:"errors,omitempty"`
 }
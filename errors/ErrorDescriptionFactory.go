@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"sync"
 )
 
 // ErrorDescriptionFactory is a factory to create serializeable ErrorDescription
@@ -10,11 +11,40 @@ import (
 // between microservices implemented in different languages.
 // They allow to restore exceptions on the receiving side close to
 // the original type and preserve additional information.
+//
+// Internal descriptions contain complete error information to be logged.
+// External descriptions are redacted by the configured RedactionPolicy
+// to be safely returned to clients.
 //	see ErrorDescription
 //	see ApplicationError
-var ErrorDescriptionFactory = &_TErrorDescriptionFactory{}
+//	see RedactionPolicy
+var ErrorDescriptionFactory = &_TErrorDescriptionFactory{
+	redactionPolicy: NewRedactionPolicy(),
+}
+
+type _TErrorDescriptionFactory struct {
+	mtx             sync.RWMutex
+	redactionPolicy *RedactionPolicy
+}
 
-type _TErrorDescriptionFactory struct{}
+// RedactionPolicy gets the policy used to create external error descriptions.
+//	Returns: *RedactionPolicy
+func (c *_TErrorDescriptionFactory) RedactionPolicy() *RedactionPolicy {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.redactionPolicy
+}
+
+// SetRedactionPolicy sets the policy used to create external error descriptions.
+//	Parameters: policy *RedactionPolicy a redaction policy. If it is nil, a policy without patterns is used.
+func (c *_TErrorDescriptionFactory) SetRedactionPolicy(policy *RedactionPolicy) {
+	if policy == nil {
+		policy = NewEmptyRedactionPolicy()
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.redactionPolicy = policy
+}
 
 // Create creates a serializable ErrorDescription from error object.
 //	Parameters: err error an error object
@@ -23,7 +53,38 @@ func (c *_TErrorDescriptionFactory) Create(err any) *ErrorDescription {
 	return NewErrorDescription(err)
 }
 
+// CreateInternal creates a complete ErrorDescription from error object to be used inside the service,
+// for instance, in logs. It is the same as Create.
+//	Parameters: err any an error object
+//	Returns: *ErrorDescription a serializeable ErrorDescription object that describes the error.
+func (c *_TErrorDescriptionFactory) CreateInternal(err any) *ErrorDescription {
+	return NewErrorDescription(err)
+}
+
+// CreateExternal creates an ErrorDescription from error object that is safe to return to external clients.
+// Sensitive details, stack trace and cause are removed according to the redaction policy.
+//	see RedactionPolicy
+//	Parameters: err any an error object
+//	Returns: *ErrorDescription a redacted ErrorDescription object that describes the error.
+func (c *_TErrorDescriptionFactory) CreateExternal(err any) *ErrorDescription {
+	return c.RedactionPolicy().Apply(NewErrorDescription(err))
+}
+
+// CreateLocalized creates a serializable ErrorDescription from error object
+// with messages rendered for the requested locale using ErrorCatalog.
+//	see ErrorCatalog
+//	Parameters:
+//		- err any an error object
+//		- locale string a requested locale, for instance "de" or "pt-BR"
+//	Returns: *ErrorDescription a serializeable ErrorDescription object that describes the error.
+func (c *_TErrorDescriptionFactory) CreateLocalized(err any, locale string) *ErrorDescription {
+	return ErrorCatalog.Localize(NewErrorDescription(err), locale)
+}
+
 // NewErrorDescription creates a serializable ErrorDescription from error object.
+// When ApplicationError has no stack trace, it is taken from the first wrapped ApplicationError that has one.
+// For other errors the stack trace is captured according to StackTraceCapture mode.
+// When ApplicationError has no status, the default status of its category is taken from ErrorCategoryRegistry.
 //	Parameters: err any an error object
 //	Returns: *ErrorDescription a serializeable ErrorDescription object that describes the error.
 func NewErrorDescription(err any) *ErrorDescription {
@@ -37,15 +98,40 @@ func NewErrorDescription(err any) *ErrorDescription {
 	if ex, ok := err.(*ApplicationError); ok {
 		description.Category = ex.Category
 		description.Status = ex.Status
+		if description.Status == 0 {
+			description.Status, _ = ErrorCategoryRegistry.GetStatus(ex.Category)
+		}
 		description.Code = ex.Code
 		description.Message = ex.Message
 		description.Details = ex.Details
 		description.CorrelationId = ex.CorrelationId
 		description.Cause = ex.Cause
 		description.StackTrace = ex.StackTrace
+		description.Retryable = ex.Retryable
+		description.RetryAfter = ex.RetryAfter
+		description.Errors = newAggregatedDescriptions(ex.errors)
+		if description.StackTrace == "" {
+			description.StackTrace = findStackTrace(ex.cause)
+		}
 	} else if err != nil {
 		description.Message = fmt.Sprintf("%v", err)
+		if StackTraceCapture.IsEnabledFor(Unknown) {
+			description.StackTrace = StackTraceCapture.Capture(1)
+		}
 	}
 
 	return description
 }
+
+// findStackTrace searches for the first ApplicationError with a stack trace in the error chain.
+//	Parameters: err error an error chain to search
+//	Returns: string a found stack trace or empty string
+func findStackTrace(err error) string {
+	for err != nil {
+		if ex, ok := err.(*ApplicationError); ok && ex.StackTrace != "" {
+			return ex.StackTrace
+		}
+		err = Unwrap(err)
+	}
+	return ""
+}
@@ -0,0 +1,352 @@
+package errors
+
+import "sync"
+
+// Numeric gRPC status codes as defined in https://grpc.github.io/grpc/core/md_doc_statuscodes.html.
+const (
+	GrpcOk                 = 0
+	GrpcCanceled           = 1
+	GrpcUnknown            = 2
+	GrpcInvalidArgument    = 3
+	GrpcDeadlineExceeded   = 4
+	GrpcNotFound           = 5
+	GrpcAlreadyExists      = 6
+	GrpcPermissionDenied   = 7
+	GrpcResourceExhausted  = 8
+	GrpcFailedPrecondition = 9
+	GrpcAborted            = 10
+	GrpcOutOfRange         = 11
+	GrpcUnimplemented      = 12
+	GrpcInternal           = 13
+	GrpcUnavailable        = 14
+	GrpcDataLoss           = 15
+	GrpcUnauthenticated    = 16
+)
+
+// Process exit codes as defined in sysexits.h.
+const (
+	ExitOk          = 0
+	ExitFailure     = 1
+	ExitUsage       = 64
+	ExitDataErr     = 65
+	ExitNoInput     = 66
+	ExitUnavailable = 69
+	ExitSoftware    = 70
+	ExitIoErr       = 74
+	ExitTempFail    = 75
+	ExitProtocol    = 76
+	ExitNoPerm      = 77
+	ExitConfig      = 78
+)
+
+// ErrorStatusMapping is a central table that maps error categories to gRPC status codes,
+// process exit codes and HTTP statuses, with inverse lookups to rebuild errors on receipt.
+// HTTP statuses of categories are taken from ErrorCategoryRegistry.
+// The mapping is implemented as plain tables and does not depend on any transport library.
+//
+//	Category           gRPC                    Exit code  HTTP
+//	Unknown            Unknown (2)             1          500
+//	Internal           Internal (13)           70         500
+//	Misconfiguration   Internal (13)           78         500
+//	InvalidState       FailedPrecondition (9)  75         500
+//	NoResponse         Unavailable (14)        75         500
+//	FailedInvocation   Internal (13)           76         500
+//	FileError          Internal (13)           74         500
+//	BadRequest         InvalidArgument (3)     64         400
+//	Unauthorized       Unauthenticated (16)    77         401
+//	Forbidden          PermissionDenied (7)    77         403
+//	NotFound           NotFound (5)            66         404
+//	Conflict           Aborted (10)            65         409
+//	Unsupported        Unimplemented (12)      69         500
+//
+//	see ErrorCategory
+//	see ErrorCategoryRegistry
+//	Example:
+//		code := errors.ErrorStatusMapping.GrpcCodeOf(err)
+//		os.Exit(errors.ErrorStatusMapping.ExitCodeOf(err))
+//
+//		// On the receiving side
+//		err := errors.ErrorStatusMapping.CreateFromGrpcCode(errors.GrpcNotFound, "123", "NOT_FOUND", "Not found")
+var ErrorStatusMapping = newErrorStatusMapping()
+
+type _TErrorStatusMapping struct {
+	mtx            sync.RWMutex
+	grpcCodes      map[string]int
+	exitCodes      map[string]int
+	grpcCategories map[int]string
+	exitCategories map[int]string
+	httpCategories map[int]string
+}
+
+func newErrorStatusMapping() *_TErrorStatusMapping {
+	c := &_TErrorStatusMapping{
+		grpcCodes:      map[string]int{},
+		exitCodes:      map[string]int{},
+		grpcCategories: map[int]string{},
+		exitCategories: map[int]string{},
+		httpCategories: map[int]string{},
+	}
+
+	c.Register(Unknown, GrpcUnknown, ExitFailure)
+	c.Register(Internal, GrpcInternal, ExitSoftware)
+	c.Register(Misconfiguration, GrpcInternal, ExitConfig)
+	c.Register(InvalidState, GrpcFailedPrecondition, ExitTempFail)
+	c.Register(NoResponse, GrpcUnavailable, ExitTempFail)
+	c.Register(FailedInvocation, GrpcInternal, ExitProtocol)
+	c.Register(FileError, GrpcInternal, ExitIoErr)
+	c.Register(BadRequest, GrpcInvalidArgument, ExitUsage)
+	c.Register(Unauthorized, GrpcUnauthenticated, ExitNoPerm)
+	c.Register(Forbidden, GrpcPermissionDenied, ExitNoPerm)
+	c.Register(NotFound, GrpcNotFound, ExitNoInput)
+	c.Register(Conflict, GrpcAborted, ExitDataErr)
+	c.Register(Unsupported, GrpcUnimplemented, ExitUnavailable)
+
+	// Inverse gRPC codes that are not produced by the forward mapping
+	c.MapGrpcCode(GrpcCanceled, Unknown)
+	c.MapGrpcCode(GrpcDeadlineExceeded, NoResponse)
+	c.MapGrpcCode(GrpcAlreadyExists, Conflict)
+	c.MapGrpcCode(GrpcResourceExhausted, InvalidState)
+	c.MapGrpcCode(GrpcOutOfRange, BadRequest)
+	c.MapGrpcCode(GrpcDataLoss, Internal)
+
+	// Inverse exit codes shared by several categories
+	c.MapExitCode(ExitTempFail, NoResponse)
+
+	// Inverse HTTP statuses
+	c.MapHttpStatus(400, BadRequest)
+	c.MapHttpStatus(401, Unauthorized)
+	c.MapHttpStatus(403, Forbidden)
+	c.MapHttpStatus(404, NotFound)
+	c.MapHttpStatus(405, Unsupported)
+	c.MapHttpStatus(408, NoResponse)
+	c.MapHttpStatus(409, Conflict)
+	c.MapHttpStatus(412, InvalidState)
+	c.MapHttpStatus(500, Unknown)
+	c.MapHttpStatus(501, Unsupported)
+	c.MapHttpStatus(502, FailedInvocation)
+	c.MapHttpStatus(503, NoResponse)
+	c.MapHttpStatus(504, NoResponse)
+
+	return c
+}
+
+// Register registers or replaces gRPC status code and process exit code for the error category.
+// The inverse lookups are set only when the codes are not mapped yet.
+//	Parameters:
+//		- category string an error category
+//		- grpcCode int a gRPC status code
+//		- exitCode int a process exit code
+func (c *_TErrorStatusMapping) Register(category string, grpcCode int, exitCode int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.grpcCodes[category] = grpcCode
+	c.exitCodes[category] = exitCode
+	if _, ok := c.grpcCategories[grpcCode]; !ok {
+		c.grpcCategories[grpcCode] = category
+	}
+	if _, ok := c.exitCategories[exitCode]; !ok {
+		c.exitCategories[exitCode] = category
+	}
+}
+
+// MapGrpcCode sets the category used to rebuild errors received with the gRPC status code.
+//	Parameters:
+//		- grpcCode int a gRPC status code
+//		- category string an error category
+func (c *_TErrorStatusMapping) MapGrpcCode(grpcCode int, category string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.grpcCategories[grpcCode] = category
+}
+
+// MapExitCode sets the category used to rebuild errors received with the process exit code.
+//	Parameters:
+//		- exitCode int a process exit code
+//		- category string an error category
+func (c *_TErrorStatusMapping) MapExitCode(exitCode int, category string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.exitCategories[exitCode] = category
+}
+
+// MapHttpStatus sets the category used to rebuild errors received with the HTTP status.
+//	Parameters:
+//		- status int a HTTP status code
+//		- category string an error category
+func (c *_TErrorStatusMapping) MapHttpStatus(status int, category string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.httpCategories[status] = category
+}
+
+// ToGrpcCode gets gRPC status code for the error category.
+// Unmapped categories are converted to Unknown (2).
+//	Parameters: category string an error category
+//	Returns: int a gRPC status code
+func (c *_TErrorStatusMapping) ToGrpcCode(category string) int {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	if code, ok := c.grpcCodes[category]; ok {
+		return code
+	}
+	return GrpcUnknown
+}
+
+// ToExitCode gets process exit code for the error category.
+// Unmapped categories are converted to 1.
+//	Parameters: category string an error category
+//	Returns: int a process exit code
+func (c *_TErrorStatusMapping) ToExitCode(category string) int {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	if code, ok := c.exitCodes[category]; ok {
+		return code
+	}
+	return ExitFailure
+}
+
+// ToHttpStatus gets HTTP status for the error category from ErrorCategoryRegistry.
+// Unregistered categories are converted to 500.
+//	Parameters: category string an error category
+//	Returns: int a HTTP status code
+func (c *_TErrorStatusMapping) ToHttpStatus(category string) int {
+	if status, ok := ErrorCategoryRegistry.GetStatus(category); ok {
+		return status
+	}
+	return 500
+}
+
+// FromGrpcCode gets the error category for gRPC status code.
+//	Parameters: grpcCode int a gRPC status code
+//	Returns: string an error category or Unknown if the code is not mapped
+func (c *_TErrorStatusMapping) FromGrpcCode(grpcCode int) string {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	if category, ok := c.grpcCategories[grpcCode]; ok {
+		return category
+	}
+	return Unknown
+}
+
+// FromExitCode gets the error category for process exit code.
+//	Parameters: exitCode int a process exit code
+//	Returns: string an error category or Unknown if the code is not mapped
+func (c *_TErrorStatusMapping) FromExitCode(exitCode int) string {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	if category, ok := c.exitCategories[exitCode]; ok {
+		return category
+	}
+	return Unknown
+}
+
+// FromHttpStatus gets the error category for HTTP status.
+// Unmapped 4xx statuses are converted to BadRequest and others to Unknown.
+//	Parameters: status int a HTTP status code
+//	Returns: string an error category
+func (c *_TErrorStatusMapping) FromHttpStatus(status int) string {
+	c.mtx.RLock()
+	category, ok := c.httpCategories[status]
+	c.mtx.RUnlock()
+
+	if ok {
+		return category
+	}
+	if status >= 400 && status < 500 {
+		return BadRequest
+	}
+	return Unknown
+}
+
+// GrpcCodeOf gets gRPC status code for the error. Nil errors are converted to Ok (0).
+//	Parameters: err error an error
+//	Returns: int a gRPC status code
+func (c *_TErrorStatusMapping) GrpcCodeOf(err error) int {
+	if err == nil {
+		return GrpcOk
+	}
+	var ex *ApplicationError
+	if As(err, &ex) {
+		return c.ToGrpcCode(ex.Category)
+	}
+	return GrpcUnknown
+}
+
+// ExitCodeOf gets process exit code for the error. Nil errors are converted to 0.
+//	Parameters: err error an error
+//	Returns: int a process exit code
+func (c *_TErrorStatusMapping) ExitCodeOf(err error) int {
+	if err == nil {
+		return ExitOk
+	}
+	var ex *ApplicationError
+	if As(err, &ex) {
+		return c.ToExitCode(ex.Category)
+	}
+	return ExitFailure
+}
+
+// HttpStatusOf gets HTTP status for the error. The status set in ApplicationError takes precedence.
+// Nil errors are converted to 200.
+//	Parameters: err error an error
+//	Returns: int a HTTP status code
+func (c *_TErrorStatusMapping) HttpStatusOf(err error) int {
+	if err == nil {
+		return 200
+	}
+	var ex *ApplicationError
+	if As(err, &ex) {
+		if ex.Status != 0 {
+			return ex.Status
+		}
+		return c.ToHttpStatus(ex.Category)
+	}
+	return 500
+}
+
+// CreateFromGrpcCode rebuilds an error received with gRPC status code.
+//	Parameters:
+//		- grpcCode int a gRPC status code
+//		- correlationId string a unique transaction id to trace execution through call chain.
+//		- code string a unique error code.
+//		- message string a human-readable description of the error.
+//	Returns: *ApplicationError the error or nil if the code is Ok
+func (c *_TErrorStatusMapping) CreateFromGrpcCode(grpcCode int, correlationId, code, message string) *ApplicationError {
+	if grpcCode == GrpcOk {
+		return nil
+	}
+	return ErrorCategoryRegistry.Create(c.FromGrpcCode(grpcCode), correlationId, code, message)
+}
+
+// CreateFromExitCode rebuilds an error from the process exit code.
+//	Parameters:
+//		- exitCode int a process exit code
+//		- correlationId string a unique transaction id to trace execution through call chain.
+//		- code string a unique error code.
+//		- message string a human-readable description of the error.
+//	Returns: *ApplicationError the error or nil if the code is 0
+func (c *_TErrorStatusMapping) CreateFromExitCode(exitCode int, correlationId, code, message string) *ApplicationError {
+	if exitCode == ExitOk {
+		return nil
+	}
+	return ErrorCategoryRegistry.Create(c.FromExitCode(exitCode), correlationId, code, message)
+}
+
+// CreateFromHttpStatus rebuilds an error received with HTTP status. The received status is preserved.
+//	Parameters:
+//		- status int a HTTP status code
+//		- correlationId string a unique transaction id to trace execution through call chain.
+//		- code string a unique error code.
+//		- message string a human-readable description of the error.
+//	Returns: *ApplicationError the error or nil if the status is below 400
+func (c *_TErrorStatusMapping) CreateFromHttpStatus(status int, correlationId, code, message string) *ApplicationError {
+	if status < 400 {
+		return nil
+	}
+	return ErrorCategoryRegistry.Create(c.FromHttpStatus(status), correlationId, code, message).WithStatus(status)
+}
@@ -0,0 +1,199 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"net"
+	"sync"
+)
+
+// ErrorTranslation is a function that translates an error into ApplicationError.
+// It returns nil when the error cannot be translated.
+type ErrorTranslation func(correlationId string, err error) *ApplicationError
+
+// ErrorTranslator translates standard library, context and other foreign errors into application errors.
+// Translated errors keep the original error as a cause, so it is still accessible through Is and As.
+// Category, code and status are set consistently through ErrorCategoryRegistry.
+//
+// Default translations:
+//
+//	context.DeadlineExceeded - NoResponse, "TIMEOUT"
+//	context.Canceled - Unknown, "CANCELED"
+//	fs.ErrNotExist (os.ErrNotExist) - NotFound, "NOT_FOUND"
+//	fs.ErrPermission (os.ErrPermission) - Unauthorized, "ACCESS_DENIED"
+//	io.EOF, io.ErrUnexpectedEOF - NoResponse, "UNEXPECTED_EOF"
+//	net.Error with timeout - NoResponse, "TIMEOUT"
+//	other net.Error - NoResponse, "CONNECTION_FAILED"
+//	json.SyntaxError, json.UnmarshalTypeError - BadRequest, "INVALID_JSON"
+//
+// Truncated streams are translated as NoResponse rather than BadRequest,
+// because they usually come from dropped connections or incomplete upstream responses and can be retried.
+// Services that read client requests can register their own translation for them.
+//
+// Registered translations are checked before the default ones, in reverse order of registration.
+//	Example:
+//		errors.ErrorTranslator.RegisterError(sql.ErrNoRows, errors.NotFound, "NO_ROWS")
+//		errors.ErrorTranslator.SetTranslateInWrapError(true)
+//
+//		err := errors.ErrorTranslator.Translate("123", context.DeadlineExceeded) // ConnectionError with TIMEOUT code
+var ErrorTranslator = newErrorTranslator()
+
+type _TErrorTranslator struct {
+	mtx                 sync.RWMutex
+	translations        []ErrorTranslation
+	defaultTranslations []ErrorTranslation
+	translateInWrap     bool
+}
+
+func newErrorTranslator() *_TErrorTranslator {
+	c := &_TErrorTranslator{}
+
+	c.defaultTranslations = []ErrorTranslation{
+		translateByTarget(context.DeadlineExceeded, NoResponse, "TIMEOUT"),
+		translateByTarget(context.Canceled, Unknown, "CANCELED"),
+		translateByTarget(fs.ErrNotExist, NotFound, "NOT_FOUND"),
+		translateByTarget(fs.ErrPermission, Unauthorized, "ACCESS_DENIED"),
+		translateByTarget(io.ErrUnexpectedEOF, NoResponse, "UNEXPECTED_EOF"),
+		translateByTarget(io.EOF, NoResponse, "UNEXPECTED_EOF"),
+		translateJsonError,
+		translateNetError,
+	}
+
+	return c
+}
+
+// Register registers a custom translation. Registered translations take precedence over the default ones.
+//	Parameters: translation ErrorTranslation a translation function
+func (c *_TErrorTranslator) Register(translation ErrorTranslation) {
+	if translation == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.translations = append([]ErrorTranslation{translation}, c.translations...)
+}
+
+// RegisterError registers a translation for errors that match the target error by Is.
+//	Parameters:
+//		- target error an error to match
+//		- category string an error category for translated errors
+//		- code string an error code for translated errors
+func (c *_TErrorTranslator) RegisterError(target error, category string, code string) {
+	c.Register(translateByTarget(target, category, code))
+}
+
+// Reset removes all registered translations and disables translation in WrapError.
+// Default translations are kept.
+func (c *_TErrorTranslator) Reset() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.translations = nil
+	c.translateInWrap = false
+}
+
+// SetTranslateInWrapError sets if WrapError shall translate wrapped errors.
+// By default wrapped errors are not translated.
+//	Parameters: translate bool true to translate errors in WrapError
+func (c *_TErrorTranslator) SetTranslateInWrapError(translate bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.translateInWrap = translate
+}
+
+// IsTranslateInWrapError checks if WrapError translates wrapped errors.
+//	Returns: bool true if WrapError translates errors
+func (c *_TErrorTranslator) IsTranslateInWrapError() bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+
+	return c.translateInWrap
+}
+
+// TryTranslate translates the error using registered and default translations.
+//	Parameters:
+//		- correlationId string a unique transaction id to trace execution through call chain.
+//		- err error an error to translate
+//	Returns: *ApplicationError the translated error and bool true if a translation was found
+func (c *_TErrorTranslator) TryTranslate(correlationId string, err error) (*ApplicationError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	if e, ok := err.(*ApplicationError); ok {
+		return e, true
+	}
+
+	c.mtx.RLock()
+	translations := make([]ErrorTranslation, 0, len(c.translations)+len(c.defaultTranslations))
+	translations = append(translations, c.translations...)
+	translations = append(translations, c.defaultTranslations...)
+	c.mtx.RUnlock()
+
+	for _, translation := range translations {
+		if result := translation(correlationId, err); result != nil {
+			return result, true
+		}
+	}
+	return nil, false
+}
+
+// Translate translates the error into ApplicationError.
+// Errors without matching translation are wrapped into UnknownError.
+//	Parameters:
+//		- correlationId string a unique transaction id to trace execution through call chain.
+//		- err error an error to translate
+//	Returns: *ApplicationError the translated error or nil if err is nil
+func (c *_TErrorTranslator) Translate(correlationId string, err error) *ApplicationError {
+	if err == nil {
+		return nil
+	}
+	if result, ok := c.TryTranslate(correlationId, err); ok {
+		return result
+	}
+	return NewUnknownError(correlationId, "UNKNOWN", err.Error()).WithCause(err)
+}
+
+func translateByTarget(target error, category string, code string) ErrorTranslation {
+	return func(correlationId string, err error) *ApplicationError {
+		if !Is(err, target) {
+			return nil
+		}
+		return ErrorCategoryRegistry.Create(category, correlationId, code, err.Error()).WithCause(err)
+	}
+}
+
+func translateJsonError(correlationId string, err error) *ApplicationError {
+	var syntaxErr *json.SyntaxError
+	if As(err, &syntaxErr) {
+		return NewBadRequestError(correlationId, "INVALID_JSON", err.Error()).
+			WithDetails("offset", syntaxErr.Offset).
+			WithCause(err)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if As(err, &typeErr) {
+		return NewBadRequestError(correlationId, "INVALID_JSON", err.Error()).
+			WithDetails("offset", typeErr.Offset).
+			WithDetails("field", typeErr.Field).
+			WithCause(err)
+	}
+
+	return nil
+}
+
+func translateNetError(correlationId string, err error) *ApplicationError {
+	var netErr net.Error
+	if !As(err, &netErr) {
+		return nil
+	}
+
+	if netErr.Timeout() {
+		return NewConnectionError(correlationId, "TIMEOUT", err.Error()).WithCause(err)
+	}
+	return NewConnectionError(correlationId, "CONNECTION_FAILED", err.Error()).WithCause(err)
+}
@@ -0,0 +1,20 @@
+package errors
+
+// Access errors caused by insufficient security permissions of an authenticated caller (authorization error).
+
+// NewForbiddenError creates an error instance and assigns its values.
+//	see ErrorCategory
+//	Parameters:
+//		- correlation_id string a unique transaction id to trace execution through call chain.
+//		- code string a unique error code.
+//		- message string a human-readable description of the error.
+//	Returns: *ApplicationError
+func NewForbiddenError(correlationId, code, message string) *ApplicationError {
+	return captureStackTrace(&ApplicationError{
+		Category:      Forbidden,
+		CorrelationId: correlationId,
+		Code:          code,
+		Message:       message,
+		Status:        403,
+	})
+}
@@ -10,11 +10,11 @@ package errors
 //		- message string a human-readable description of the error.
 //	Returns: *ApplicationError
 func NewInternalError(correlationId, code, message string) *ApplicationError {
-	return &ApplicationError{
+	return captureStackTrace(&ApplicationError{
 		Category:      Internal,
 		CorrelationId: correlationId,
 		Code:          code,
 		Message:       message,
 		Status:        500,
-	}
+	})
 }
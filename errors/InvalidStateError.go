@@ -11,11 +11,11 @@ package errors
 //		- message string a human-readable description of the error.
 //	Returns: *ApplicationError
 func NewInvalidStateError(correlationId, code, message string) *ApplicationError {
-	return &ApplicationError{
+	return captureStackTrace(&ApplicationError{
 		Category:      InvalidState,
 		CorrelationId: correlationId,
 		Code:          code,
 		Message:       message,
 		Status:        500,
-	}
+	})
 }
@@ -10,11 +10,11 @@ package errors
 //		- message string a human-readable description of the error.
 //	Returns: *ApplicationError
 func NewNotFoundError(correlationId, code, message string) *ApplicationError {
-	return &ApplicationError{
+	return captureStackTrace(&ApplicationError{
 		Category:      NotFound,
 		CorrelationId: correlationId,
 		Code:          code,
 		Message:       message,
 		Status:        404,
-	}
+	})
 }
@@ -0,0 +1,267 @@
+package errors
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// ProblemJsonContentType is a content type of RFC 7807 problem documents.
+const ProblemJsonContentType = "application/problem+json"
+
+// ProblemDetails is a RFC 7807 problem document used to pass errors through HTTP.
+// Standard members are mapped from ErrorDescription as:
+//
+//	type - an URI composed from the error category and code
+//	title - a human-readable error message
+//	status - HTTP status code
+//	detail - a human-readable error message
+//	instance - (optional) an URI that identifies the specific occurrence of the problem
+//
+// The category, code, correlation_id and details of the error are passed as extension members.
+// Other extension members received from remote side are kept in Extensions.
+//	see ErrorDescription
+//	see ProblemDetailsFactory
+type ProblemDetails struct {
+	Type       string         `json:"type"`
+	Title      string         `json:"title"`
+	Status     int            `json:"status"`
+	Detail     string         `json:"detail"`
+	Instance   string         `json:"instance"`
+	Extensions map[string]any `json:"-"`
+}
+
+var problemStandardMembers = map[string]bool{
+	"type": true, "title": true, "status": true, "detail": true, "instance": true,
+}
+
+// MarshalJSON encodes the problem document with extension members at the top level.
+//	Returns: []byte, error
+func (p *ProblemDetails) MarshalJSON() ([]byte, error) {
+	values := make(map[string]any, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		if !problemStandardMembers[key] {
+			values[key] = value
+		}
+	}
+
+	if p.Type != "" {
+		values["type"] = p.Type
+	}
+	if p.Title != "" {
+		values["title"] = p.Title
+	}
+	if p.Status != 0 {
+		values["status"] = p.Status
+	}
+	if p.Detail != "" {
+		values["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		values["instance"] = p.Instance
+	}
+
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON decodes the problem document and collects unknown members into Extensions.
+//	Parameters: data []byte a JSON document
+//	Returns: error
+func (p *ProblemDetails) UnmarshalJSON(data []byte) error {
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	*p = ProblemDetails{Type: "about:blank"}
+	for key, value := range values {
+		switch key {
+		case "type":
+			p.Type, _ = value.(string)
+		case "title":
+			p.Title, _ = value.(string)
+		case "status":
+			if status, ok := value.(float64); ok {
+				p.Status = int(status)
+			}
+		case "detail":
+			p.Detail, _ = value.(string)
+		case "instance":
+			p.Instance, _ = value.(string)
+		default:
+			if p.Extensions == nil {
+				p.Extensions = map[string]any{}
+			}
+			p.Extensions[key] = value
+		}
+	}
+
+	return nil
+}
+
+// ProblemDetailsFactory is a factory to convert errors into RFC 7807 problem documents and back.
+// Error category and code are encoded into the problem type as <prefix><category>:<code>.
+//	see ProblemDetails
+//	Example:
+//		problem := errors.ProblemDetailsFactory.Create(errors.NewNotFoundError("123", "NO_ITEM", "Item not found"))
+//		// problem.Type == "urn:pip:error:NotFound:NO_ITEM"
+//
+//		data, _ := json.Marshal(problem)
+//		w.Header().Set("Content-Type", errors.ProblemJsonContentType)
+//		w.WriteHeader(problem.Status)
+//		w.Write(data)
+//
+//		// On the receiving side
+//		var received errors.ProblemDetails
+//		json.Unmarshal(data, &received)
+//		err := errors.ProblemDetailsFactory.ToError(&received) // NotFoundError
+var ProblemDetailsFactory = &_TProblemDetailsFactory{
+	typePrefix: "urn:pip:error:",
+}
+
+type _TProblemDetailsFactory struct {
+	mtx        sync.RWMutex
+	typePrefix string
+}
+
+// TypePrefix gets the prefix of problem type URIs.
+//	Returns: string
+func (c *_TProblemDetailsFactory) TypePrefix() string {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.typePrefix
+}
+
+// SetTypePrefix sets the prefix of problem type URIs, for instance "https://example.com/errors/".
+//	Parameters: prefix string a type URI prefix
+func (c *_TProblemDetailsFactory) SetTypePrefix(prefix string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.typePrefix = prefix
+}
+
+// Create creates a problem document from error object that is safe to return to external clients.
+// Sensitive details and causes are removed according to the redaction policy.
+//	see ErrorDescriptionFactory.CreateExternal
+//	Parameters: err any an error object
+//	Returns: *ProblemDetails
+func (c *_TProblemDetailsFactory) Create(err any) *ProblemDetails {
+	return c.CreateFromDescription(ErrorDescriptionFactory.CreateExternal(err))
+}
+
+// CreateFromDescription creates a problem document from ErrorDescription.
+// The title is a short summary that does not change between occurrences: the catalog message
+// of the error code when it has no placeholders, or the error category.
+//	Parameters: description *ErrorDescription an error description
+//	Returns: *ProblemDetails
+func (c *_TProblemDetailsFactory) CreateFromDescription(description *ErrorDescription) *ProblemDetails {
+	if description == nil {
+		return nil
+	}
+
+	problemType := c.TypePrefix() + description.Category
+	if description.Code != "" {
+		problemType += ":" + description.Code
+	}
+
+	problem := &ProblemDetails{
+		Type:   problemType,
+		Title:  problemTitle(description),
+		Status: description.Status,
+		Detail: description.Message,
+		Extensions: map[string]any{
+			"category": description.Category,
+			"code":     description.Code,
+		},
+	}
+	if description.CorrelationId != "" {
+		problem.Extensions["correlation_id"] = description.CorrelationId
+	}
+	if len(description.Details) > 0 {
+		problem.Extensions["details"] = description.Details
+	}
+
+	return problem
+}
+
+// ToDescription converts a problem document into ErrorDescription.
+// Category and code are taken from extension members or parsed from the problem type.
+// If they are missing, the category is resolved by HTTP status using ErrorStatusMapping.
+//	Parameters: problem *ProblemDetails a problem document
+//	Returns: *ErrorDescription
+func (c *_TProblemDetailsFactory) ToDescription(problem *ProblemDetails) *ErrorDescription {
+	if problem == nil {
+		return nil
+	}
+
+	description := &ErrorDescription{
+		Status:  problem.Status,
+		Message: problem.Detail,
+	}
+	if description.Message == "" {
+		description.Message = problem.Title
+	}
+
+	if category, ok := problem.Extensions["category"].(string); ok {
+		description.Category = category
+	}
+	if code, ok := problem.Extensions["code"].(string); ok {
+		description.Code = code
+	}
+	if correlationId, ok := problem.Extensions["correlation_id"].(string); ok {
+		description.CorrelationId = correlationId
+	}
+	if details, ok := problem.Extensions["details"].(map[string]any); ok {
+		description.Details = details
+	}
+
+	prefix := c.TypePrefix()
+	if description.Category == "" && prefix != "" && strings.HasPrefix(problem.Type, prefix) {
+		parts := strings.SplitN(strings.TrimPrefix(problem.Type, prefix), ":", 2)
+		description.Category = parts[0]
+		if len(parts) > 1 && description.Code == "" {
+			description.Code = parts[1]
+		}
+	}
+
+	if description.Category == "" {
+		description.Category = ErrorStatusMapping.FromHttpStatus(problem.Status)
+	}
+	if description.Code == "" {
+		description.Code = "UNKNOWN"
+	}
+
+	return description
+}
+
+// ToError restores ApplicationError of the right type from a problem document.
+//	Parameters: problem *ProblemDetails a problem document
+//	Returns: *ApplicationError
+func (c *_TProblemDetailsFactory) ToError(problem *ProblemDetails) *ApplicationError {
+	return NewErrorFromDescription(c.ToDescription(problem))
+}
+
+// problemTitle gets a short summary of the problem type from the error catalog or the error category.
+func problemTitle(description *ErrorDescription) string {
+	if description.Code != "" {
+		if definition, ok := ErrorCatalog.Get(description.Code); ok && definition.Message != "" &&
+			!strings.Contains(definition.Message, "{{") {
+			return definition.Message
+		}
+	}
+
+	category := description.Category
+	if category == "" {
+		category = Unknown
+	}
+	// Split category names like "BadRequest" into words
+	title := strings.Builder{}
+	for index, char := range category {
+		if index > 0 && unicode.IsUpper(char) {
+			title.WriteRune(' ')
+		}
+		title.WriteRune(char)
+	}
+	return title.String()
+}
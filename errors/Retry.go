@@ -0,0 +1,45 @@
+package errors
+
+// IsRetryable checks if a failed call that returned the error is safe to retry.
+// It walks through the chain of wrapped errors. An explicit retry classification found
+// in any ApplicationError in the chain wins, otherwise the default for the category
+// of the outermost categorized ApplicationError is used. Other errors
+// are treated as retryable when they report a timeout.
+//	Parameters: err error an error to check
+//	Returns: bool true if the call can be retried
+func IsRetryable(err error) bool {
+	var first *ApplicationError
+	for e := err; e != nil; e = Unwrap(e) {
+		if ex, ok := e.(*ApplicationError); ok {
+			if ex.Retryable != nil {
+				return *ex.Retryable
+			}
+			if first == nil && ex.Category != "" {
+				first = ex
+			}
+		}
+	}
+
+	if first != nil {
+		return first.IsRetryable()
+	}
+
+	var timeout interface{ Timeout() bool }
+	if As(err, &timeout) {
+		return timeout.Timeout()
+	}
+	return false
+}
+
+// GetRetryAfter gets a hint when a failed call can be retried.
+// It returns the first non-zero hint found in the chain of wrapped errors.
+//	Parameters: err error an error to check
+//	Returns: int64 a delay in milliseconds and bool true if the hint was found
+func GetRetryAfter(err error) (int64, bool) {
+	for e := err; e != nil; e = Unwrap(e) {
+		if ex, ok := e.(*ApplicationError); ok && ex.RetryAfter > 0 {
+			return ex.RetryAfter, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,147 @@
+package errors
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StackTraceMode defines when stack traces are captured for application errors.
+//	Possible values:
+//		- StackTraceOff - stack traces are never captured (default)
+//		- StackTraceInternal - stack traces are captured only for Internal and Unknown errors
+//		- StackTraceAlways - stack traces are captured for all errors
+type StackTraceMode int
+
+const (
+	StackTraceOff StackTraceMode = iota
+	StackTraceInternal
+	StackTraceAlways
+)
+
+// StackTraceCapture is a configurable helper that captures stack traces
+// when application errors are created or wrapped.
+// Captured traces are stored in ApplicationError.StackTrace and passed through the wire
+// inside ErrorDescription, so errors from remote services can be debugged.
+//	Example:
+//		errors.StackTraceCapture.SetMode(errors.StackTraceInternal)
+//		errors.StackTraceCapture.SetFilter(func(frame runtime.Frame) bool {
+//			return !strings.HasPrefix(frame.Function, "net/http.")
+//		})
+//
+//		err := errors.NewInternalError("123", "FAILED", "Something went wrong")
+//		fmt.Printf("%+v", err)
+var StackTraceCapture = &_TStackTraceCapture{
+	mode:     StackTraceOff,
+	maxDepth: 32,
+}
+
+type _TStackTraceCapture struct {
+	mu       sync.RWMutex
+	mode     StackTraceMode
+	maxDepth int
+	filter   func(frame runtime.Frame) bool
+}
+
+var errorsPackagePrefix = reflect.TypeOf(ApplicationError{}).PkgPath() + "."
+
+// Mode gets the current stack trace capture mode.
+//	Returns: StackTraceMode
+func (c *_TStackTraceCapture) Mode() StackTraceMode {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.mode
+}
+
+// SetMode sets when stack traces shall be captured.
+//	Parameters: mode StackTraceMode a capture mode
+func (c *_TStackTraceCapture) SetMode(mode StackTraceMode) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mode = mode
+}
+
+// SetMaxDepth sets the maximum number of frames kept in a stack trace.
+//	Parameters: maxDepth int a maximum number of frames. Values <= 0 are ignored.
+func (c *_TStackTraceCapture) SetMaxDepth(maxDepth int) {
+	if maxDepth <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxDepth = maxDepth
+}
+
+// SetFilter sets a frame filter applied to captured stack traces.
+// Frames of the Go runtime and of this package are always removed.
+//	Parameters: filter func(frame runtime.Frame) bool returns true to keep the frame or nil to keep all frames
+func (c *_TStackTraceCapture) SetFilter(filter func(frame runtime.Frame) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.filter = filter
+}
+
+// IsEnabledFor checks if stack trace shall be captured for the error category.
+//	Parameters: category string an error category
+//	Returns: bool true if the stack trace shall be captured
+func (c *_TStackTraceCapture) IsEnabledFor(category string) bool {
+	switch c.Mode() {
+	case StackTraceAlways:
+		return true
+	case StackTraceInternal:
+		return category == Internal || category == Unknown || category == ""
+	default:
+		return false
+	}
+}
+
+// Capture captures the stack trace of the calling goroutine and formats it as a string.
+//	Parameters: skip int a number of caller frames to skip
+//	Returns: string a formatted stack trace
+func (c *_TStackTraceCapture) Capture(skip int) string {
+	c.mu.RLock()
+	maxDepth := c.maxDepth
+	filter := c.filter
+	c.mu.RUnlock()
+
+	pcs := make([]uintptr, maxDepth+16)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	builder := strings.Builder{}
+	depth := 0
+	for depth < maxDepth {
+		frame, more := frames.Next()
+		if frame.Function != "" && !strings.HasPrefix(frame.Function, "runtime.") &&
+			!strings.HasPrefix(frame.Function, errorsPackagePrefix) &&
+			(filter == nil || filter(frame)) {
+			if depth > 0 {
+				builder.WriteString("\n")
+			}
+			builder.WriteString(frame.Function)
+			builder.WriteString("\n\t")
+			builder.WriteString(frame.File)
+			builder.WriteString(":")
+			builder.WriteString(strconv.Itoa(frame.Line))
+			depth++
+		}
+		if !more {
+			break
+		}
+	}
+
+	return builder.String()
+}
+
+// captureStackTrace fills stack trace of the error when it is enabled for the error category
+// and the error does not have a stack trace yet.
+//	Parameters: e *ApplicationError an error to fill
+//	Returns: *ApplicationError the same error
+func captureStackTrace(e *ApplicationError) *ApplicationError {
+	if e.StackTrace == "" && StackTraceCapture.IsEnabledFor(e.Category) {
+		e.StackTrace = StackTraceCapture.Capture(1)
+	}
+	return e
+}
@@ -11,11 +11,11 @@ package errors
 //		- message string a human-readable description of the error.
 //	Returns: *ApplicationError
 func NewUnauthorizedError(correlationId, code, message string) *ApplicationError {
-	return &ApplicationError{
+	return captureStackTrace(&ApplicationError{
 		Category:      Unauthorized,
 		CorrelationId: correlationId,
 		Code:          code,
 		Message:       message,
 		Status:        401,
-	}
+	})
 }
@@ -0,0 +1,8 @@
+package refer
+
+import "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+
+// Registers error codes raised by references in the error catalog.
+func init() {
+	errors.ErrorCatalog.Register("REF_ERROR", errors.Internal, 500, "Failed to obtain reference to {{locator}}")
+}
@@ -0,0 +1,212 @@
+package run
+
+import (
+	"context"
+)
+
+// Context keys of call metadata: correlation id, trace ids, caller principal, tenant and idempotency key.
+// Deadlines and cancellation are out of scope of these helpers, since context.Context carries them itself.
+// Use context.WithTimeout or context.WithDeadline, or commands.TimeoutInterceptor for command timeouts.
+const (
+	ContextCorrelationIdType  ContextValueType = "pip.CorrelationId"
+	ContextTraceIdType        ContextValueType = "pip.TraceId"
+	ContextSpanIdType         ContextValueType = "pip.SpanId"
+	ContextPrincipalType      ContextValueType = "pip.Principal"
+	ContextTenantType         ContextValueType = "pip.Tenant"
+	ContextIdempotencyKeyType ContextValueType = "pip.IdempotencyKey"
+)
+
+// Principal describes an authenticated caller.
+//
+//	id - a unique caller id
+//	name - a human-readable caller name
+//	roles - roles granted to the caller
+//	claims - additional claims about the caller, like scopes or attributes
+type Principal struct {
+	Id     string         `json:"id"`
+	Name   string         `json:"name,omitempty"`
+	Roles  []string       `json:"roles,omitempty"`
+	Claims map[string]any `json:"claims,omitempty"`
+}
+
+// NewPrincipal creates a new caller principal.
+//	Parameters:
+//		- id string a unique caller id
+//		- roles ...string roles granted to the caller
+//	Returns: *Principal
+func NewPrincipal(id string, roles ...string) *Principal {
+	return &Principal{
+		Id:     id,
+		Roles:  roles,
+		Claims: map[string]any{},
+	}
+}
+
+// WithName sets a human-readable caller name.
+//	Parameters: name string a caller name
+//	Returns: *Principal
+func (c *Principal) WithName(name string) *Principal {
+	c.Name = name
+	return c
+}
+
+// WithClaim adds a claim about the caller.
+//	Parameters:
+//		- key string a claim key
+//		- value any a claim value
+//	Returns: *Principal
+func (c *Principal) WithClaim(key string, value any) *Principal {
+	if c.Claims == nil {
+		c.Claims = map[string]any{}
+	}
+	c.Claims[key] = value
+	return c
+}
+
+// HasRole checks if the role is granted to the caller.
+//	Parameters: role string a role to check
+//	Returns: bool true if the caller has the role
+func (c *Principal) HasRole(role string) bool {
+	if c == nil {
+		return false
+	}
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClaim gets a claim about the caller.
+//	Parameters: key string a claim key
+//	Returns: (any, bool) the claim value and true if the claim is defined
+func (c *Principal) GetClaim(key string) (any, bool) {
+	if c == nil || c.Claims == nil {
+		return nil, false
+	}
+	value, ok := c.Claims[key]
+	return value, ok
+}
+
+// WithCorrelationId adds a correlation id to the context.
+// Empty ids are not added.
+//	Parameters:
+//		- ctx context.Context a parent context
+//		- correlationId string transaction id to trace execution through call chain
+//	Returns: context.Context a context with the correlation id
+func WithCorrelationId(ctx context.Context, correlationId string) context.Context {
+	if correlationId == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ContextCorrelationIdType, correlationId)
+}
+
+// GetCorrelationId gets a correlation id from the context.
+//	Parameters: ctx context.Context a context
+//	Returns: string the correlation id or empty string if it is not set
+func GetCorrelationId(ctx context.Context) string {
+	return getContextString(ctx, ContextCorrelationIdType)
+}
+
+// WithTraceIds adds distributed trace and span ids to the context.
+// Empty ids are not added.
+//	Parameters:
+//		- ctx context.Context a parent context
+//		- traceId string a trace id
+//		- spanId string a span id
+//	Returns: context.Context a context with the trace ids
+func WithTraceIds(ctx context.Context, traceId string, spanId string) context.Context {
+	if traceId != "" {
+		ctx = context.WithValue(ctx, ContextTraceIdType, traceId)
+	}
+	if spanId != "" {
+		ctx = context.WithValue(ctx, ContextSpanIdType, spanId)
+	}
+	return ctx
+}
+
+// GetTraceId gets a distributed trace id from the context.
+//	Parameters: ctx context.Context a context
+//	Returns: string the trace id or empty string if it is not set
+func GetTraceId(ctx context.Context) string {
+	return getContextString(ctx, ContextTraceIdType)
+}
+
+// GetSpanId gets a distributed span id from the context.
+//	Parameters: ctx context.Context a context
+//	Returns: string the span id or empty string if it is not set
+func GetSpanId(ctx context.Context) string {
+	return getContextString(ctx, ContextSpanIdType)
+}
+
+// WithPrincipal adds a caller principal to the context.
+//	Parameters:
+//		- ctx context.Context a parent context
+//		- principal *Principal an authenticated caller
+//	Returns: context.Context a context with the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	if principal == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ContextPrincipalType, principal)
+}
+
+// GetPrincipal gets a caller principal from the context.
+//	Parameters: ctx context.Context a context
+//	Returns: *Principal the principal or nil if the caller is anonymous
+func GetPrincipal(ctx context.Context) *Principal {
+	if ctx == nil {
+		return nil
+	}
+	principal, _ := ctx.Value(ContextPrincipalType).(*Principal)
+	return principal
+}
+
+// WithTenant adds a tenant id to the context.
+// Empty ids are not added.
+//	Parameters:
+//		- ctx context.Context a parent context
+//		- tenant string a tenant id
+//	Returns: context.Context a context with the tenant id
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	if tenant == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ContextTenantType, tenant)
+}
+
+// GetTenant gets a tenant id from the context.
+//	Parameters: ctx context.Context a context
+//	Returns: string the tenant id or empty string if it is not set
+func GetTenant(ctx context.Context) string {
+	return getContextString(ctx, ContextTenantType)
+}
+
+// WithIdempotencyKey adds a key to detect repeated calls to the context.
+// Empty keys are not added.
+//	Parameters:
+//		- ctx context.Context a parent context
+//		- key string an idempotency key
+//	Returns: context.Context a context with the idempotency key
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	if key == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ContextIdempotencyKeyType, key)
+}
+
+// GetIdempotencyKey gets a key to detect repeated calls from the context.
+//	Parameters: ctx context.Context a context
+//	Returns: string the idempotency key or empty string if it is not set
+func GetIdempotencyKey(ctx context.Context) string {
+	return getContextString(ctx, ContextIdempotencyKeyType)
+}
+
+func getContextString(ctx context.Context, key ContextValueType) string {
+	if ctx == nil {
+		return ""
+	}
+	value, _ := ctx.Value(key).(string)
+	return value
+}
@@ -30,11 +30,32 @@ func (c *_TCleaner) ClearOne(ctx context.Context, correlationId string, componen
 //		- components []any the list of components that are to be cleaned.
 //	Returns: error
 func (c *_TCleaner) Clear(ctx context.Context, correlationId string, components []any) error {
-	for _, component := range components {
+	return c.ClearWithMode(ctx, correlationId, components, StopOnFailure)
+}
+
+// ClearWithMode clears state of multiple components using the specified failure mode.
+// In ContinueOnFailure mode all components are cleaned and failures are reported in an aggregate error.
+//	see Clear
+//	see FailureMode
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId string transaction id to trace execution through call chain.
+//		- components []any the list of components that are to be cleaned.
+//		- mode FailureMode defines how to handle failures.
+//	Returns: error
+func (c *_TCleaner) ClearWithMode(ctx context.Context, correlationId string, components []any, mode FailureMode) error {
+	var failed []int
+	var errs []error
+
+	for index, component := range components {
 		err := c.ClearOne(ctx, correlationId, component)
 		if err != nil {
-			return err
+			if mode != ContinueOnFailure {
+				return err
+			}
+			failed = append(failed, index)
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return newAggregateFailure(correlationId, "CLEAR_FAILED", "clear", components, failed, errs)
 }
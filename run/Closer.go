@@ -32,10 +32,31 @@ func (c *_TCloser) CloseOne(ctx context.Context, correlationId string, component
 //		- components []any the list of components that are to be closed.
 //	Returns: error
 func (c *_TCloser) Close(ctx context.Context, correlationId string, components []any) error {
-	for _, component := range components {
+	return c.CloseWithMode(ctx, correlationId, components, StopOnFailure)
+}
+
+// CloseWithMode closes multiple components using the specified failure mode.
+// In ContinueOnFailure mode all components are closed and failures are reported in an aggregate error.
+//	see Close
+//	see FailureMode
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId string transaction id to trace execution through call chain.
+//		- components []any the list of components that are to be closed.
+//		- mode FailureMode defines how to handle failures.
+//	Returns: error
+func (c *_TCloser) CloseWithMode(ctx context.Context, correlationId string, components []any, mode FailureMode) error {
+	var failed []int
+	var errs []error
+
+	for index, component := range components {
 		if err := c.CloseOne(ctx, correlationId, component); err != nil {
-			return err
+			if mode != ContinueOnFailure {
+				return err
+			}
+			failed = append(failed, index)
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return newAggregateFailure(correlationId, "CLOSE_FAILED", "close", components, failed, errs)
 }
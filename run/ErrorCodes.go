@@ -0,0 +1,12 @@
+package run
+
+import "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+
+// Registers error codes raised by component helpers in the error catalog.
+// These codes are used by aggregate errors in ContinueOnFailure mode, failures of each component are nested in them.
+func init() {
+	errors.ErrorCatalog.Register("OPEN_FAILED", errors.Unknown, 500, "Failed to open components")
+	errors.ErrorCatalog.Register("CLOSE_FAILED", errors.Unknown, 500, "Failed to close components")
+	errors.ErrorCatalog.Register("CLEAR_FAILED", errors.Unknown, 500, "Failed to clear components")
+	errors.ErrorCatalog.Register("EXECUTE_FAILED", errors.Unknown, 500, "Failed to execute components")
+}
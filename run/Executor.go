@@ -34,15 +34,40 @@ func (c *_TExecutor) ExecuteOne(ctx context.Context, correlationId string, compo
 //		- args *Parameters execution arguments.
 //	Returns: []any, error execution result or error
 func (c *_TExecutor) Execute(ctx context.Context, correlationId string, components []any, args *Parameters) ([]any, error) {
+	return c.ExecuteWithMode(ctx, correlationId, components, args, StopOnFailure)
+}
+
+// ExecuteWithMode executes multiple components using the specified failure mode.
+// In StopOnFailure mode results of successfully executed components are returned together with the first error.
+// In ContinueOnFailure mode all components are executed, results are aligned with components
+// (nil for failed ones) and failures are reported in an aggregate error.
+//	see Execute
+//	see FailureMode
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId string transaction id to trace execution through call chain.
+//		- components []any a list of components that are to be executed.
+//		- args *Parameters execution arguments.
+//		- mode FailureMode defines how to handle failures.
+//	Returns: []any, error execution result or error
+func (c *_TExecutor) ExecuteWithMode(ctx context.Context, correlationId string, components []any,
+	args *Parameters, mode FailureMode) ([]any, error) {
+
 	results := make([]any, 0, 5)
+	var failed []int
+	var errs []error
 
-	for _, component := range components {
+	for index, component := range components {
 		result, err := c.ExecuteOne(ctx, correlationId, component, args)
 		if err != nil {
-			return results, err
+			if mode != ContinueOnFailure {
+				return results, err
+			}
+			failed = append(failed, index)
+			errs = append(errs, err)
 		}
 		results = append(results, result)
 	}
 
-	return results, nil
+	return results, newAggregateFailure(correlationId, "EXECUTE_FAILED", "execute", components, failed, errs)
 }
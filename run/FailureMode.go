@@ -0,0 +1,51 @@
+package run
+
+import (
+	"fmt"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+)
+
+// FailureMode defines how helpers process multiple components when some of them fail.
+//	Possible values:
+//		- StopOnFailure - processing stops at the first failure that is returned as is (default)
+//		- ContinueOnFailure - all components are processed and every failure is reported in an aggregate error
+type FailureMode int
+
+const (
+	StopOnFailure FailureMode = iota
+	ContinueOnFailure
+)
+
+// newAggregateFailure creates an aggregate error that describes failures of multiple components.
+//	see errors.NewAggregateError
+//	Parameters:
+//		- correlationId string transaction id to trace execution through call chain.
+//		- code string a unique error code.
+//		- action string a failed action name used in the error message.
+//		- components []any the list of processed components.
+//		- failed []int the list of indexes of failed components.
+//		- errs []error the list of errors aligned with the failed indexes.
+//	Returns: error an aggregate error or nil if there are no failures
+func newAggregateFailure(correlationId, code, action string, components []any, failed []int, errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("Failed to %s %d component(s)", action, len(errs))
+	err := errors.NewAggregateError(correlationId, code, message)
+	for i, e := range errs {
+		err.AddComponentError(componentName(failed[i], components[failed[i]]), e)
+	}
+	return err
+}
+
+// componentName identifies a component in aggregate errors.
+// Components that have a name are identified by it, others by their type.
+// The position of the component in the list is added to tell apart components of the same type.
+func componentName(index int, component any) string {
+	if named, ok := component.(interface{ Name() string }); ok && named.Name() != "" {
+		return fmt.Sprintf("%s[%d]", named.Name(), index)
+	}
+	return fmt.Sprintf("%T[%d]", component, index)
+}
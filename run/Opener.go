@@ -65,10 +65,31 @@ func (c *_TOpener) OpenOne(ctx context.Context, correlationId string, component
 //		- components []any the list of components that are to be closed.
 //	Returns: error
 func (c *_TOpener) Open(ctx context.Context, correlationId string, components []any) error {
-	for _, component := range components {
+	return c.OpenWithMode(ctx, correlationId, components, StopOnFailure)
+}
+
+// OpenWithMode opens multiple components using the specified failure mode.
+// In ContinueOnFailure mode all components are opened and failures are reported in an aggregate error.
+//	see Open
+//	see FailureMode
+//	Parameters:
+//		- ctx context.Context
+//		- correlationId string transaction id to trace execution through call chain.
+//		- components []any the list of components that are to be opened.
+//		- mode FailureMode defines how to handle failures.
+//	Returns: error
+func (c *_TOpener) OpenWithMode(ctx context.Context, correlationId string, components []any, mode FailureMode) error {
+	var failed []int
+	var errs []error
+
+	for index, component := range components {
 		if err := c.OpenOne(ctx, correlationId, component); err != nil {
-			return err
+			if mode != ContinueOnFailure {
+				return err
+			}
+			failed = append(failed, index)
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return newAggregateFailure(correlationId, "OPEN_FAILED", "open", components, failed, errs)
 }
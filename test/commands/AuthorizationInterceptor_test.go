@@ -0,0 +1,171 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func newAuthorizedCommandSet(interceptor *commands.AuthorizationInterceptor) *commands.CommandSet {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+	for _, name := range []string{"get_orders", "get_secret", "delete_order", "ping", "other"} {
+		commandSet.AddCommand(newSleepCommand(name, 0))
+	}
+	return commandSet
+}
+
+func assertAuthError(t *testing.T, err error, code string) {
+	var appErr *errors.ApplicationError
+	if assert.True(t, errors.As(err, &appErr)) {
+		assert.Equal(t, code, appErr.Code)
+		if code == "COMMAND_ACCESS_DENIED" {
+			assert.Equal(t, errors.Forbidden, appErr.Category)
+			assert.Equal(t, 403, appErr.Status)
+			assert.Equal(t, errors.GrpcPermissionDenied, errors.ErrorStatusMapping.GrpcCodeOf(err))
+		} else {
+			assert.Equal(t, errors.Unauthorized, appErr.Category)
+			assert.Equal(t, 401, appErr.Status)
+			assert.Equal(t, errors.GrpcUnauthenticated, errors.ErrorStatusMapping.GrpcCodeOf(err))
+		}
+	}
+}
+
+func TestAuthorizationInterceptor(t *testing.T) {
+	interceptor := commands.NewAuthorizationInterceptor().
+		WithRule(commands.NewAuthorizationRule("get_*").WithRoles("user", "admin")).
+		WithRule(commands.NewAuthorizationRule("get_secret").WithRoles("admin")).
+		WithRule(commands.NewAuthorizationRule("delete_*").WithClaim("scope", "orders:write")).
+		WithRule(commands.NewAuthorizationRule("ping").WithAnonymous())
+	commandSet := newAuthorizedCommandSet(interceptor)
+
+	user := run.WithPrincipal(context.Background(),
+		run.NewPrincipal("user1", "user").WithClaim("scope", []string{"orders:read", "orders:write"}))
+	guest := run.WithPrincipal(context.Background(), run.NewPrincipal("guest1", "guest"))
+	anonymous := context.Background()
+
+	_, err := commandSet.Execute(user, "123", "get_orders", nil)
+	assert.Nil(t, err)
+	_, err = commandSet.Execute(user, "123", "delete_order", nil)
+	assert.Nil(t, err)
+	_, err = commandSet.Execute(anonymous, "123", "ping", nil)
+	assert.Nil(t, err)
+
+	// The most specific rule is applied
+	_, err = commandSet.Execute(user, "123", "get_secret", nil)
+	assertAuthError(t, err, "COMMAND_ACCESS_DENIED")
+
+	_, err = commandSet.Execute(guest, "123", "get_orders", nil)
+	assertAuthError(t, err, "COMMAND_ACCESS_DENIED")
+	var appErr *errors.ApplicationError
+	errors.As(err, &appErr)
+	assert.Equal(t, "get_orders", appErr.Details["command"])
+	assert.Equal(t, "guest1", appErr.Details["principal"])
+
+	_, err = commandSet.Execute(guest, "123", "delete_order", nil)
+	assertAuthError(t, err, "COMMAND_ACCESS_DENIED")
+
+	_, err = commandSet.Execute(anonymous, "123", "get_orders", nil)
+	assertAuthError(t, err, "NOT_AUTHENTICATED")
+
+	// Commands without rules are denied by default
+	_, err = commandSet.Execute(user, "123", "other", nil)
+	assertAuthError(t, err, "COMMAND_ACCESS_DENIED")
+
+	interceptor.WithAllowUnmatched(true)
+	_, err = commandSet.Execute(user, "123", "other", nil)
+	assert.Nil(t, err)
+}
+
+func TestAuthorizationInterceptorConfig(t *testing.T) {
+	interceptor := commands.NewAuthorizationInterceptor().
+		WithRule(commands.NewAuthorizationRule("get_*").WithRoles("admin"))
+	interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+		"authorization.allow_unmatched", true,
+		"authorization.rules.get_*.roles", "user, admin",
+		"authorization.rules.delete_*.claims.scope", "*",
+		"authorization.rules.ping.anonymous", true,
+	))
+	commandSet := newAuthorizedCommandSet(interceptor)
+
+	user := run.WithPrincipal(context.Background(), run.NewPrincipal("user1", "user"))
+
+	_, err := commandSet.Execute(user, "123", "get_orders", nil)
+	assert.Nil(t, err)
+	_, err = commandSet.Execute(user, "123", "delete_order", nil)
+	assertAuthError(t, err, "COMMAND_ACCESS_DENIED")
+	_, err = commandSet.Execute(run.WithPrincipal(context.Background(),
+		run.NewPrincipal("user2").WithClaim("scope", "any")), "123", "delete_order", nil)
+	assert.Nil(t, err)
+	_, err = commandSet.Execute(context.Background(), "123", "ping", nil)
+	assert.Nil(t, err)
+	_, err = commandSet.Execute(user, "123", "other", nil)
+	assert.Nil(t, err)
+}
+
+func TestAuthorizationBeforeValidation(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewAuthorizationInterceptor().
+		WithRule(commands.NewAuthorizationRule("get_secret").WithRoles("admin")))
+	commandSet.AddCommand(commands.NewCommand("get_secret",
+		validate.NewObjectSchema().WithRequiredProperty("id", convert.String),
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			return "secret", nil
+		}))
+
+	admin := run.WithPrincipal(context.Background(), run.NewPrincipal("admin1", "admin"))
+	guest := run.WithPrincipal(context.Background(), run.NewPrincipal("guest1", "guest"))
+
+	// Unauthorized callers do not get validation errors
+	_, err := commandSet.Execute(guest, "123", "get_secret", run.NewEmptyParameters())
+	assertAuthError(t, err, "COMMAND_ACCESS_DENIED")
+	_, err = commandSet.Execute(context.Background(), "123", "get_secret", run.NewEmptyParameters())
+	assertAuthError(t, err, "NOT_AUTHENTICATED")
+
+	_, err = commandSet.Execute(admin, "123", "get_secret", run.NewEmptyParameters())
+	var appErr *errors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, errors.BadRequest, appErr.Category)
+
+	result, err := commandSet.Execute(admin, "123", "get_secret", run.NewParametersFromTuples("id", "1"))
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", result)
+}
+
+func TestAuthorizationBeforeBatchValidation(t *testing.T) {
+	interceptor := commands.NewAuthorizationInterceptor().
+		WithRule(commands.NewAuthorizationRule("get_secret").WithRoles("admin"))
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+	commandSet.AddCommand(commands.NewCommand("get_secret",
+		validate.NewObjectSchema().WithRequiredProperty("id", convert.String),
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			return "secret", nil
+		}))
+
+	admin := run.WithPrincipal(context.Background(), run.NewPrincipal("admin1", "admin"))
+	guest := run.WithPrincipal(context.Background(), run.NewPrincipal("guest1", "guest"))
+	batch := []*commands.BatchCommand{commands.NewBatchCommand("get_secret", run.NewEmptyParameters())}
+
+	// Unauthorized callers do not get validation errors of batch commands
+	results, err := commandSet.ExecuteBatch(guest, "123", batch, commands.BatchSequential)
+	assert.NotNil(t, err)
+	assertAuthError(t, results[0].Err, "COMMAND_ACCESS_DENIED")
+	assert.False(t, results[0].Executed)
+
+	results, _ = commandSet.ExecuteBatch(admin, "123", batch, commands.BatchSequential)
+	var appErr *errors.ApplicationError
+	assert.True(t, errors.As(results[0].Err, &appErr))
+	assert.Equal(t, errors.BadRequest, appErr.Category)
+
+	// Commands called through the chain directly are still checked
+	_, err = interceptor.Execute(guest, "123", commandSet.FindCommand("get_secret"), run.NewEmptyParameters())
+	assertAuthError(t, err, "COMMAND_ACCESS_DENIED")
+}
@@ -0,0 +1,159 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerInterceptor(t *testing.T) {
+	interceptor := commands.NewCircuitBreakerInterceptor()
+	interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+		"circuit_breaker.failure_threshold", 2,
+		"circuit_breaker.reset_timeout", 50,
+	))
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+
+	command, calls := newFailingCommand("command", 3, cerrors.NewInternalError("123", "FAILED", "Failed"))
+	commandSet.AddCommand(command)
+
+	for i := 0; i < 2; i++ {
+		_, err := commandSet.Execute(context.Background(), "123", "command", nil)
+		assert.Equal(t, "FAILED", err.(*cerrors.ApplicationError).Code)
+	}
+	assert.Equal(t, commands.CircuitOpen, interceptor.GetState("command"))
+
+	_, err := commandSet.Execute(context.Background(), "123", "command", nil)
+	assert.Equal(t, "CIRCUIT_OPEN", err.(*cerrors.ApplicationError).Code)
+	assert.Equal(t, 503, err.(*cerrors.ApplicationError).Status)
+	assert.Equal(t, 2, *calls)
+
+	// Failed trial execution opens the circuit again
+	time.Sleep(60 * time.Millisecond)
+	_, err = commandSet.Execute(context.Background(), "123", "command", nil)
+	assert.Equal(t, "FAILED", err.(*cerrors.ApplicationError).Code)
+	assert.Equal(t, commands.CircuitOpen, interceptor.GetState("command"))
+
+	// Successful trial execution closes the circuit
+	time.Sleep(60 * time.Millisecond)
+	result, err := commandSet.Execute(context.Background(), "123", "command", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, result)
+	assert.Equal(t, commands.CircuitClosed, interceptor.GetState("command"))
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	interceptor := commands.NewCircuitBreakerInterceptor()
+	interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+		"circuit_breaker.failure_threshold", 1,
+	))
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+
+	command, _ := newFailingCommand("command", 3, cerrors.NewNotFoundError("123", "NOT_FOUND", "Not found"))
+	commandSet.AddCommand(command)
+
+	for i := 0; i < 3; i++ {
+		_, err := commandSet.Execute(context.Background(), "123", "command", nil)
+		assert.Equal(t, "NOT_FOUND", err.(*cerrors.ApplicationError).Code)
+	}
+	assert.Equal(t, commands.CircuitClosed, interceptor.GetState("command"))
+}
+
+// panickingCommand is a command that does not recover panics of its action.
+type panickingCommand struct {
+	name string
+}
+
+func (c *panickingCommand) Name() string {
+	return c.name
+}
+
+func (c *panickingCommand) Validate(args *run.Parameters) []*validate.ValidationResult {
+	return nil
+}
+
+func (c *panickingCommand) Execute(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+	panic("Execution " + c.name + " failed")
+}
+
+func TestCircuitBreakerFailedTrials(t *testing.T) {
+	interceptor := commands.NewCircuitBreakerInterceptor()
+	interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+		"circuit_breaker.failure_threshold", 1,
+		"circuit_breaker.reset_timeout", 20,
+		"circuit_breaker.trial_timeout", 50,
+	))
+
+	newCommand := func(action func(ctx context.Context) (any, error)) commands.ICommand {
+		return commands.NewCommand("command", nil,
+			func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+				return action(ctx)
+			})
+	}
+	failing := newCommand(func(ctx context.Context) (any, error) {
+		return nil, cerrors.NewInternalError("123", "FAILED", "Failed")
+	})
+
+	_, err := interceptor.Execute(context.Background(), "123", failing, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, commands.CircuitOpen, interceptor.GetState("command"))
+
+	// Panics in trial executions open the circuit again
+	time.Sleep(30 * time.Millisecond)
+	assert.Panics(t, func() {
+		interceptor.Execute(context.Background(), "123", &panickingCommand{name: "command"}, nil)
+	})
+	assert.Equal(t, commands.CircuitOpen, interceptor.GetState("command"))
+
+	// Trial executions get a deadline
+	time.Sleep(30 * time.Millisecond)
+	_, err = interceptor.Execute(context.Background(), "123", newCommand(func(ctx context.Context) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}), nil)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, commands.CircuitOpen, interceptor.GetState("command"))
+
+	// Trials that ignore the deadline do not keep the circuit half-open
+	time.Sleep(30 * time.Millisecond)
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		interceptor.Execute(context.Background(), "123", newCommand(func(ctx context.Context) (any, error) {
+			<-release
+			return nil, nil
+		}), nil)
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, commands.CircuitHalfOpen, interceptor.GetState("command"))
+
+	time.Sleep(50 * time.Millisecond)
+	_, err = interceptor.Execute(context.Background(), "123", failing, nil)
+	assert.Equal(t, "CIRCUIT_OPEN", err.(*cerrors.ApplicationError).Code)
+	assert.Equal(t, commands.CircuitOpen, interceptor.GetState("command"))
+
+	// Results of expired trials are ignored
+	close(release)
+	<-done
+	assert.Equal(t, commands.CircuitOpen, interceptor.GetState("command"))
+
+	time.Sleep(30 * time.Millisecond)
+	result, err := interceptor.Execute(context.Background(), "123", newCommand(func(ctx context.Context) (any, error) {
+		return "ok", nil
+	}), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, commands.CircuitClosed, interceptor.GetState("command"))
+}
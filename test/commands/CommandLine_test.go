@@ -0,0 +1,130 @@
+package test_commands
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCommandLine() (*commands.CommandLine, *bytes.Buffer, *bytes.Buffer) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(newSumCommand())
+	commandSet.AddCommand(commands.NewCommand(
+		"get_item",
+		validate.NewObjectSchema().
+			WithRequiredProperty("id", convert.String).
+			WithOptionalProperty("verbose", convert.Boolean).
+			WithOptionalProperty("filter", validate.NewObjectSchema().
+				WithOptionalProperty("limit", convert.Integer)),
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			if args.GetAsString("id") == "0" {
+				return nil, errors.NewNotFoundError(correlationId, "ITEM_NOT_FOUND", "Item not found").
+					WithDetails("id", "0")
+			}
+			return map[string]any{
+				"id":      args.GetAsString("id"),
+				"verbose": args.GetAsBoolean("verbose"),
+				"limit":   args.GetAsInteger("filter.limit"),
+			}, nil
+		},
+	))
+
+	out := &bytes.Buffer{}
+	errOut := &bytes.Buffer{}
+	cli := commands.NewCommandLine("items", commandSet).WithOutput(out, errOut)
+	return cli, out, errOut
+}
+
+func TestCommandLineParse(t *testing.T) {
+	cli, _, _ := newCommandLine()
+
+	args, err := cli.Parse("", "get_item", []string{"--id", "5", "--verbose", "--filter.limit=10"})
+	assert.Nil(t, err)
+	assert.Equal(t, "5", args.GetAsString("id"))
+	value, _ := args.Get("verbose")
+	assert.Equal(t, true, value)
+	value, _ = args.Get("filter.limit")
+	assert.Equal(t, int64(10), value)
+
+	args, err = cli.Parse("", "get_item", []string{`{"id": "7", "filter": {"limit": 3}}`})
+	assert.Nil(t, err)
+	assert.Equal(t, "7", args.GetAsString("id"))
+	assert.Equal(t, 3, args.GetAsInteger("filter.limit"))
+
+	_, err = cli.Parse("", "get_item", []string{"not json"})
+	assert.NotNil(t, err)
+
+	// Values without schema are kept as strings
+	args, err = cli.Parse("", "get_item", []string{"--id", "5", "--code", "007", "--flag", "true", "--filter", `{"limit": 2}`})
+	assert.Nil(t, err)
+	value, _ = args.Get("code")
+	assert.Equal(t, "007", value)
+	value, _ = args.Get("flag")
+	assert.Equal(t, "true", value)
+	assert.Equal(t, 2, args.GetAsInteger("filter.limit"))
+}
+
+func TestCommandLineRun(t *testing.T) {
+	cli, out, _ := newCommandLine()
+
+	code := cli.Run(context.Background(), []string{"get_item", "--id", "5", "--filter.limit", "10"})
+	assert.Equal(t, 0, code)
+	assert.JSONEq(t, `{"id": "5", "verbose": false, "limit": 10}`, out.String())
+
+	out.Reset()
+	code = cli.Run(context.Background(), []string{"--format", "table", "get_item", "--id", "5"})
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out.String(), "id")
+	assert.Contains(t, out.String(), "limit")
+	assert.NotContains(t, out.String(), "{")
+
+	// Options after the command name are command arguments
+	out.Reset()
+	code = cli.Run(context.Background(), []string{"get_item", "--id", "5", "--format", "table"})
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out.String(), "{")
+}
+
+func TestCommandLineErrors(t *testing.T) {
+	cli, _, errOut := newCommandLine()
+
+	code := cli.Run(context.Background(), []string{"get_item", "--id", "0"})
+	assert.Equal(t, errors.ExitNoInput, code)
+	assert.Contains(t, errOut.String(), "ITEM_NOT_FOUND")
+
+	errOut.Reset()
+	code = cli.Run(context.Background(), []string{"get_item", "--verbose", "maybe"})
+	assert.Equal(t, errors.ExitUsage, code)
+	assert.Contains(t, errOut.String(), "INVALID_DATA")
+
+	code = cli.Run(context.Background(), []string{"unknown"})
+	assert.Equal(t, errors.ExitUsage, code)
+
+	code = cli.Run(context.Background(), []string{"--format", "xml", "get_item", "--id", "5"})
+	assert.Equal(t, errors.ExitUsage, code)
+}
+
+func TestCommandLineHelp(t *testing.T) {
+	cli, out, _ := newCommandLine()
+
+	code := cli.Run(context.Background(), []string{"help"})
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out.String(), "get_item")
+	assert.Contains(t, out.String(), "sum")
+
+	out.Reset()
+	code = cli.Run(context.Background(), []string{"get_item", "--help"})
+	assert.Equal(t, 0, code)
+	assert.Contains(t, out.String(), "--id")
+	assert.Contains(t, out.String(), "required")
+	assert.Contains(t, out.String(), "--verbose")
+
+	assert.Equal(t, "", cli.Help("unknown"))
+}
@@ -0,0 +1,129 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func newVersionCommand(name string, result string) commands.ICommand {
+	return commands.NewCommand(name, nil,
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			return result, nil
+		})
+}
+
+func TestParseVersionedCommandName(t *testing.T) {
+	name, version := commands.ParseVersionedCommandName("get_items@v2")
+	assert.Equal(t, "get_items", name)
+	assert.Equal(t, 2, version)
+
+	name, version = commands.ParseVersionedCommandName("get_items")
+	assert.Equal(t, "get_items", name)
+	assert.Equal(t, 0, version)
+
+	name, version = commands.ParseVersionedCommandName("user@example")
+	assert.Equal(t, "user@example", name)
+	assert.Equal(t, 0, version)
+}
+
+func TestVersionedCommands(t *testing.T) {
+	sunset := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddVersionedCommand(newVersionCommand("get_items", "v1"), commands.NewCommandMetadata(1).
+		WithAliases("get_data").
+		WithDeprecation("2.0", "get_items@v2", sunset))
+	commandSet.AddVersionedCommand(newVersionCommand("get_items", "v2"), commands.NewCommandMetadata(2).
+		WithAliases("list_items"))
+	commandSet.AddCommand(newVersionCommand("ping", "pong"))
+	commandSet.AddInterceptor(&passInterceptor{})
+
+	deprecated := []string{}
+	commandSet.SetDeprecationHandler(func(ctx context.Context, correlationId string,
+		command string, metadata *commands.CommandMetadata) {
+		assert.Equal(t, "get_items@v2", metadata.Deprecation.Replacement)
+		deprecated = append(deprecated, command)
+	})
+
+	for name, expected := range map[string]string{
+		"get_items":    "v2",
+		"get_items@v2": "v2",
+		"get_items@v1": "v1",
+		"get_data":     "v1",
+		"get_data@v2":  "v2",
+		"list_items":   "v2",
+		"ping":         "pong",
+		"ping@v1":      "pong",
+	} {
+		result, err := commandSet.Execute(context.Background(), "123", name, nil)
+		assert.Nil(t, err, name)
+		assert.Equal(t, expected, result, name)
+	}
+	assert.ElementsMatch(t, []string{"get_items@v1", "get_data"}, deprecated)
+
+	_, err := commandSet.Execute(context.Background(), "123", "get_items@v3", nil)
+	assert.NotNil(t, err)
+
+	metadata := commandSet.GetCommandMetadata("get_items@v1")
+	assert.True(t, metadata.IsDeprecated())
+	assert.Equal(t, sunset, metadata.Deprecation.Sunset)
+	assert.False(t, commandSet.GetCommandMetadata("get_items").IsDeprecated())
+	assert.Nil(t, commandSet.GetCommandMetadata("unknown"))
+
+	// Commands are listed once with their latest versions
+	registered := commandSet.Commands()
+	if assert.Len(t, registered, 2) {
+		assert.Equal(t, "get_items", registered[0].Name())
+		result, _ := registered[0].Execute(context.Background(), "123", nil)
+		assert.Equal(t, "v2", result)
+		assert.Equal(t, "ping", registered[1].Name())
+	}
+	assert.Equal(t, []int{1, 2}, commandSet.CommandVersions("get_items"))
+	assert.Equal(t, []int{1, 2}, commandSet.CommandVersions("list_items"))
+	assert.Nil(t, commandSet.CommandVersions("unknown"))
+}
+
+func TestReplaceVersionedCommand(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddVersionedCommand(newVersionCommand("get_items", "old"), commands.NewCommandMetadata(1).
+		WithAliases("get_data"))
+	commandSet.AddVersionedCommand(newVersionCommand("get_items", "new"), commands.NewCommandMetadata(1).
+		WithAliases("list_items"))
+	// Command names take precedence over aliases
+	commandSet.AddVersionedCommand(newVersionCommand("ping", "pong"), commands.NewCommandMetadata(1).
+		WithAliases("get_items"))
+
+	assert.Len(t, commandSet.Commands(), 2)
+
+	result, err := commandSet.Execute(context.Background(), "123", "get_items", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "new", result)
+
+	result, err = commandSet.Execute(context.Background(), "123", "list_items", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "new", result)
+
+	assert.Nil(t, commandSet.FindCommand("get_data"))
+}
+
+func TestExportVersionedCommands(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddVersionedCommand(newVersionCommand("get_items", "v1"), commands.NewCommandMetadata(1).
+		WithDeprecation("2.0", "get_items@v2", time.Time{}))
+	commandSet.AddVersionedCommand(newVersionCommand("get_items", "v2"), commands.NewCommandMetadata(2))
+
+	exporter := commands.NewCommandSetExporter(commandSet)
+	paths := exporter.ToOpenApi()["paths"].(map[string]any)
+	assert.Len(t, paths, 2)
+
+	operation := paths["/get_items@v1"].(map[string]any)["post"].(map[string]any)
+	assert.Equal(t, true, operation["deprecated"])
+	assert.NotContains(t, paths["/get_items"].(map[string]any)["post"], "deprecated")
+
+	assert.Contains(t, exporter.ToMarkdown(), "**Deprecated since 2.0. Use get_items@v2 instead.**")
+}
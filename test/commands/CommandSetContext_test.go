@@ -0,0 +1,95 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteSyncsCorrelationId(t *testing.T) {
+	var ctxId, argId string
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(commands.NewCommand("command", nil,
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			ctxId = run.GetCorrelationId(ctx)
+			argId = correlationId
+			return nil, nil
+		},
+	))
+
+	// From context to parameter
+	ctx := run.WithCorrelationId(context.Background(), "123")
+	_, err := commandSet.Execute(ctx, "", "command", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "123", argId)
+	assert.Equal(t, "123", ctxId)
+
+	// From parameter to context
+	_, err = commandSet.Execute(context.Background(), "456", "command", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "456", ctxId)
+
+	// Explicit parameter takes precedence
+	_, err = commandSet.Execute(ctx, "789", "command", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "789", argId)
+	assert.Equal(t, "789", ctxId)
+
+	// Generated ids are put into context
+	_, err = commandSet.Execute(context.Background(), "", "command", nil)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", argId)
+	assert.Equal(t, argId, ctxId)
+}
+
+type contextListener struct {
+	ctxId string
+	argId string
+}
+
+func (c *contextListener) OnEvent(ctx context.Context, correlationId string, e commands.IEvent, value *run.Parameters) {
+	c.ctxId = run.GetCorrelationId(ctx)
+	c.argId = correlationId
+}
+
+func TestNotifySyncsCorrelationId(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddEvent(commands.NewEvent("event"))
+	listener := &contextListener{}
+	commandSet.AddListener(listener)
+
+	commandSet.Notify(run.WithCorrelationId(context.Background(), "123"), "", "event", nil)
+	assert.Equal(t, "123", listener.argId)
+	assert.Equal(t, "123", listener.ctxId)
+
+	commandSet.Notify(context.Background(), "456", "event", nil)
+	assert.Equal(t, "456", listener.ctxId)
+}
+
+func TestExecuteBatchSyncsCorrelationId(t *testing.T) {
+	argIds := []string{}
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(commands.NewCommand("command", nil,
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			assert.Equal(t, correlationId, run.GetCorrelationId(ctx))
+			argIds = append(argIds, correlationId)
+			return nil, nil
+		},
+	))
+	batch := []*commands.BatchCommand{{Command: "command"}, {Command: "command"}}
+
+	ctx := run.WithCorrelationId(context.Background(), "123")
+	_, err := commandSet.ExecuteBatch(ctx, "", batch, commands.BatchSequential)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"123", "123"}, argIds)
+
+	// Generated ids are shared by all commands
+	argIds = []string{}
+	_, err = commandSet.ExecuteBatch(context.Background(), "", batch, commands.BatchSequential)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", argIds[0])
+	assert.Equal(t, argIds[0], argIds[1])
+}
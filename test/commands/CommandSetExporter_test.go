@@ -0,0 +1,93 @@
+package test_commands
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func newExportedCommandSet() *commands.CommandSet {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(commands.NewCommand("get_item", validate.NewObjectSchema().
+		WithRequiredProperty("id", convert.String).
+		WithOptionalProperty("count", convert.Integer, validate.NewValueComparisonRule(">", 0)),
+		commandExec))
+	commandSet.AddCommand(commands.NewCommand("ping", nil, commandExec))
+	commandSet.AddCommand(newSumCommand())
+	commandSet.AddInterceptor(commands.NewTimeoutInterceptor(1000))
+	commandSet.AddEvent(commands.NewEvent("item_changed"))
+	return commandSet
+}
+
+func TestCommandSetExporterJsonSchemas(t *testing.T) {
+	exporter := commands.NewCommandSetExporter(newExportedCommandSet())
+	schemas := exporter.ToJsonSchemas()
+
+	assert.Len(t, schemas, 3)
+	assert.Equal(t, map[string]any{"type": "object"}, schemas["ping"])
+
+	sum := schemas["sum"].(map[string]any)
+	assert.Nil(t, sum["required"])
+	assert.Contains(t, sum["properties"], "a")
+}
+
+func TestCommandSetExporterOpenApi(t *testing.T) {
+	exporter := commands.NewCommandSetExporter(newExportedCommandSet()).
+		WithTitle("Items").
+		WithVersion("2.0.0").
+		WithBasePath("/v2/items/")
+
+	document, err := exporter.ToOpenApiJson()
+	assert.Nil(t, err)
+
+	var openApi map[string]any
+	assert.Nil(t, json.Unmarshal([]byte(document), &openApi))
+	assert.Equal(t, "3.1.0", openApi["openapi"])
+	assert.Equal(t, "Items", openApi["info"].(map[string]any)["title"])
+	assert.Equal(t, []any{"item_changed"}, openApi["x-events"])
+
+	paths := openApi["paths"].(map[string]any)
+	assert.Len(t, paths, 3)
+
+	operation := paths["/v2/items/get_item"].(map[string]any)["post"].(map[string]any)
+	assert.Equal(t, "get_item", operation["operationId"])
+	schema := operation["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+	assert.Equal(t, []any{"id"}, schema["required"])
+	count := schema["properties"].(map[string]any)["count"].(map[string]any)
+	assert.Equal(t, 0.0, count["exclusiveMinimum"])
+
+	errorSchema := openApi["components"].(map[string]any)["schemas"].(map[string]any)["ErrorDescription"].(map[string]any)
+	assert.Contains(t, errorSchema["properties"], "code")
+}
+
+func TestCommandSetExporterMarkdown(t *testing.T) {
+	markdown := commands.NewCommandSetExporter(newExportedCommandSet()).
+		WithTitle("Items").
+		ToMarkdown()
+
+	assert.True(t, strings.HasPrefix(markdown, "# Items\n"))
+	assert.Contains(t, markdown, "### get_item\n\n`POST /get_item`")
+	assert.Contains(t, markdown, "| id | string | yes |  |")
+	assert.Contains(t, markdown, "| count | integer (int32) | no | `exclusiveMinimum: 0` |")
+	assert.Contains(t, markdown, "### ping\n\n`POST /ping`\n\nNo arguments defined.")
+	assert.Contains(t, markdown, "## Events\n\n- item_changed\n")
+}
+
+func TestCommandSetExporterAliases(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddVersionedCommand(commands.NewCommand("get_item", nil, commandExec),
+		commands.NewCommandMetadata(1).WithAliases("get_data", "read_item"))
+	exporter := commands.NewCommandSetExporter(commandSet)
+
+	paths := exporter.ToOpenApi()["paths"].(map[string]any)
+	operation := paths["/get_item"].(map[string]any)["post"].(map[string]any)
+	assert.Equal(t, []string{"get_data", "read_item"}, operation["x-aliases"])
+
+	markdown := exporter.ToMarkdown()
+	assert.Contains(t, markdown, "Aliases: `get_data`, `read_item`\n")
+}
@@ -0,0 +1,100 @@
+package test_commands
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingListener struct {
+	count int32
+}
+
+func (c *countingListener) OnEvent(ctx context.Context, correlationId string, e commands.IEvent, value *run.Parameters) {
+	atomic.AddInt32(&c.count, 1)
+}
+
+type passInterceptor struct{}
+
+func (c *passInterceptor) Name(command commands.ICommand) string {
+	return command.Name()
+}
+
+func (c *passInterceptor) Execute(ctx context.Context, correlationId string, command commands.ICommand, args *run.Parameters) (any, error) {
+	return command.Execute(ctx, correlationId, args)
+}
+
+func (c *passInterceptor) Validate(command commands.ICommand, args *run.Parameters) []*validate.ValidationResult {
+	return command.Validate(args)
+}
+
+func TestConcurrentCommandSet(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(commands.NewCommand("command", nil, commandExec))
+	commandSet.AddEvent(commands.NewEvent("event"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(4)
+		go func(i int) {
+			defer wg.Done()
+			commandSet.AddCommand(commands.NewCommand(fmt.Sprintf("command%d", i), nil, commandExec))
+		}(i)
+		go func() {
+			defer wg.Done()
+			commandSet.AddInterceptor(&passInterceptor{})
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := commandSet.Execute(context.Background(), "", "command", nil)
+			assert.Nil(t, err)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			commandSet.AddEvent(commands.NewEvent(fmt.Sprintf("event%d", i)))
+			commandSet.Notify(context.Background(), "", "event", nil)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, commandSet.Commands(), 21)
+	assert.Len(t, commandSet.Events(), 21)
+	assert.NotNil(t, commandSet.FindCommand("command19"))
+}
+
+func TestConcurrentEventListeners(t *testing.T) {
+	event := commands.NewEvent("event")
+	listeners := make([]*countingListener, 20)
+
+	var wg sync.WaitGroup
+	for i := range listeners {
+		listeners[i] = &countingListener{}
+		wg.Add(3)
+		go func(listener *countingListener) {
+			defer wg.Done()
+			event.AddListener(listener)
+		}(listeners[i])
+		go func() {
+			defer wg.Done()
+			event.Notify(context.Background(), "", nil)
+		}()
+		go func(listener *countingListener) {
+			defer wg.Done()
+			event.RemoveListener(listener)
+			event.AddListener(listener)
+		}(listeners[i])
+	}
+	wg.Wait()
+
+	event.Notify(context.Background(), "", nil)
+	for _, listener := range event.Listeners() {
+		assert.True(t, atomic.LoadInt32(&listener.(*countingListener).count) > 0)
+	}
+}
@@ -0,0 +1,194 @@
+package test_commands
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingListener struct {
+	mtx    sync.Mutex
+	values []int
+	delay  time.Duration
+}
+
+func (c *recordingListener) OnEvent(ctx context.Context, correlationId string, e commands.IEvent, value *run.Parameters) {
+	if correlationId == "panic" {
+		panic("Listener failed")
+	}
+	time.Sleep(c.delay)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.values = append(c.values, value.GetAsInteger("value"))
+}
+
+func (c *recordingListener) Values() []int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return append([]int{}, c.values...)
+}
+
+func TestAsyncDispatchOrderPerListener(t *testing.T) {
+	dispatcher := commands.NewEventDispatcher(4, 10).WithOrdering(commands.OrderPerListener)
+	defer dispatcher.Close(context.Background())
+
+	event := commands.NewEvent("event")
+	event.SetDispatcher(dispatcher)
+
+	listener1 := &recordingListener{}
+	listener2 := &recordingListener{delay: time.Millisecond}
+	event.AddListener(listener1)
+	event.AddListener(listener2)
+
+	expected := []int{}
+	for i := 0; i < 20; i++ {
+		event.Notify(context.Background(), "123", run.NewParametersFromTuples("value", i))
+		expected = append(expected, i)
+	}
+
+	assert.Nil(t, dispatcher.Flush(context.Background()))
+	assert.Equal(t, expected, listener1.Values())
+	assert.Equal(t, expected, listener2.Values())
+}
+
+func TestAsyncDispatchPanicIsolation(t *testing.T) {
+	var mtx sync.Mutex
+	var errs []error
+
+	dispatcher := commands.NewEventDispatcher(2, 10).
+		WithOrdering(commands.OrderPerCorrelationId).
+		WithErrorHandler(func(ctx context.Context, correlationId string, event commands.IEvent,
+			listener commands.IEventListener, err error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			errs = append(errs, err)
+		})
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddEvent(commands.NewEvent("event"))
+	commandSet.SetEventDispatcher(dispatcher)
+
+	listener := &recordingListener{}
+	commandSet.AddListener(listener)
+
+	commandSet.Notify(context.Background(), "panic", "event", run.NewParametersFromTuples("value", 1))
+	commandSet.Notify(context.Background(), "123", "event", run.NewParametersFromTuples("value", 2))
+
+	assert.Nil(t, dispatcher.Close(context.Background()))
+	assert.Equal(t, []int{2}, listener.Values())
+	assert.Len(t, errs, 1)
+	assert.True(t, cerrors.Is(errs[0], cerrors.NewInvocationError("", "EVENT_FAILED", "")))
+
+	// Notifications after close are reported as errors
+	commandSet.Notify(context.Background(), "123", "event", run.NewParametersFromTuples("value", 3))
+	assert.Len(t, errs, 2)
+	assert.Equal(t, []int{2}, listener.Values())
+}
+
+func TestAsyncDispatchFlushTimeout(t *testing.T) {
+	dispatcher := commands.NewEventDispatcher(1, 10)
+	defer dispatcher.Close(context.Background())
+
+	event := commands.NewEvent("event")
+	event.SetDispatcher(dispatcher)
+	event.AddListener(&recordingListener{delay: 100 * time.Millisecond})
+	event.Notify(context.Background(), "123", run.NewParametersFromTuples("value", 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, dispatcher.Flush(ctx))
+}
+
+type chainingListener struct {
+	release chan struct{}
+	next    commands.IEvent
+}
+
+func (c *chainingListener) OnEvent(ctx context.Context, correlationId string, e commands.IEvent, value *run.Parameters) {
+	<-c.release
+	c.next.Notify(ctx, correlationId, value)
+}
+
+func TestAsyncDispatchCloseWithFullQueue(t *testing.T) {
+	dispatcher := commands.NewEventDispatcher(1, 1)
+
+	inner := commands.NewEvent("inner")
+	inner.SetDispatcher(dispatcher)
+	inner.AddListener(&recordingListener{})
+
+	listener := &chainingListener{release: make(chan struct{}), next: inner}
+	outer := commands.NewEvent("outer")
+	outer.SetDispatcher(dispatcher)
+	outer.AddListener(listener)
+
+	// The first notification occupies the worker, the second one fills the queue
+	// and the third one waits for free space
+	for i := 0; i < 3; i++ {
+		go outer.Notify(context.Background(), "123", run.NewParametersFromTuples("value", i))
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	closed := make(chan error)
+	go func() {
+		closed <- dispatcher.Close(context.Background())
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(listener.release)
+
+	select {
+	case err := <-closed:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "Dispatcher is deadlocked")
+	}
+}
+
+type blockingListener struct {
+	release chan struct{}
+}
+
+func (c *blockingListener) OnEvent(ctx context.Context, correlationId string, e commands.IEvent, value *run.Parameters) {
+	<-c.release
+}
+
+func TestAsyncDispatchSlowListener(t *testing.T) {
+	dispatcher := commands.NewEventDispatcher(2, 1)
+	defer dispatcher.Close(context.Background())
+
+	slow := commands.NewEvent("slow")
+	slow.SetDispatcher(dispatcher)
+	blocking := &blockingListener{release: make(chan struct{})}
+	slow.AddListener(blocking)
+
+	fast := commands.NewEvent("fast")
+	fast.SetDispatcher(dispatcher)
+	listener := &recordingListener{}
+	fast.AddListener(listener)
+
+	// A slow listener occupies one worker, and other notifications are delivered by the free worker
+	slow.Notify(context.Background(), "123", run.NewParametersFromTuples("value", 0))
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			fast.Notify(context.Background(), "123", run.NewParametersFromTuples("value", i))
+		}
+		close(published)
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		assert.Fail(t, "Publisher is stalled by slow listener")
+	}
+	assert.Eventually(t, func() bool { return len(listener.Values()) == 10 }, time.Second, time.Millisecond)
+
+	close(blocking.release)
+	assert.Nil(t, dispatcher.Flush(context.Background()))
+}
@@ -0,0 +1,90 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type tenantListener struct {
+	tenant string
+}
+
+func (c *tenantListener) OnEvent(ctx context.Context, correlationId string, e commands.IEvent, value *run.Parameters) {
+	c.tenant = value.GetAsString("tenant")
+}
+
+func TestEventInterceptors(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddEvent(commands.NewEvent("order.created"))
+	commandSet.AddEvent(commands.NewEvent("heartbeat"))
+
+	trace := []string{}
+
+	// Observe
+	commandSet.AddEventInterceptor(commands.EventInterceptorFunc(
+		func(ctx context.Context, correlationId string, event commands.IEvent, args *run.Parameters) {
+			trace = append(trace, "before:"+event.Name())
+			event.Notify(ctx, correlationId, args)
+			trace = append(trace, "after:"+event.Name())
+		}))
+
+	// Suppress
+	commandSet.AddEventInterceptor(commands.EventInterceptorFunc(
+		func(ctx context.Context, correlationId string, event commands.IEvent, args *run.Parameters) {
+			if event.Name() != "heartbeat" {
+				event.Notify(ctx, correlationId, args)
+			}
+		}))
+
+	// Enrich and transform
+	commandSet.AddEventInterceptor(commands.EventInterceptorFunc(
+		func(ctx context.Context, correlationId string, event commands.IEvent, args *run.Parameters) {
+			args = args.Override(run.NewParametersFromTuples(
+				"tenant", run.GetTenant(ctx),
+				"value", args.GetAsInteger("value")*10,
+			), false)
+			event.Notify(ctx, correlationId, args)
+		}))
+
+	listener := &journalListener{}
+	commandSet.AddListener(listener)
+	tenantListener := &tenantListener{}
+	commandSet.AddListener(tenantListener)
+
+	ctx := run.WithTenant(context.Background(), "tenant1")
+	commandSet.Notify(ctx, "123", "order.created", run.NewParametersFromTuples("value", 1))
+	commandSet.Notify(ctx, "123", "heartbeat", run.NewParametersFromTuples("value", 2))
+
+	assert.Equal(t, []string{"order.created:123"}, listener.events)
+	assert.Equal(t, []int{10}, listener.values)
+	assert.Equal(t, "tenant1", tenantListener.tenant)
+	assert.Equal(t, []string{"before:order.created", "after:order.created",
+		"before:heartbeat", "after:heartbeat"}, trace)
+}
+
+func TestSuppressedEventsAreNotRecorded(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddEvent(commands.NewEvent("event1"))
+	commandSet.AddEvent(commands.NewEvent("event2"))
+
+	store := commands.NewMemoryEventStore(0)
+	commandSet.SetEventJournal(commands.NewEventJournal(store))
+	commandSet.AddEventInterceptor(commands.EventInterceptorFunc(
+		func(ctx context.Context, correlationId string, event commands.IEvent, args *run.Parameters) {
+			if event.Name() == "event1" {
+				event.Notify(ctx, correlationId, args)
+			}
+		}))
+
+	commandSet.Notify(context.Background(), "123", "event1", nil)
+	commandSet.Notify(context.Background(), "123", "event2", nil)
+
+	records, err := store.Read(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, "event1", records[0].Event)
+}
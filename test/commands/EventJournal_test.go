@@ -0,0 +1,186 @@
+package test_commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type journalListener struct {
+	events []string
+	values []int
+}
+
+func (c *journalListener) OnEvent(ctx context.Context, correlationId string, e commands.IEvent, value *run.Parameters) {
+	c.events = append(c.events, e.Name()+":"+correlationId)
+	c.values = append(c.values, value.GetAsInteger("value"))
+}
+
+func testEventJournal(t *testing.T, store commands.IEventStore) {
+	journal := commands.NewEventJournal(store)
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddEvent(commands.NewEvent("created"))
+	commandSet.AddEvent(commands.NewEvent("deleted"))
+	commandSet.SetEventJournal(journal)
+
+	listener := &journalListener{}
+	commandSet.AddListener(listener)
+
+	start := time.Now().UTC()
+	commandSet.Notify(context.Background(), "1", "created", run.NewParametersFromTuples("value", 1))
+	commandSet.Notify(context.Background(), "2", "created", run.NewParametersFromTuples("value", 2))
+	commandSet.Notify(context.Background(), "2", "deleted", run.NewParametersFromTuples("value", 3))
+	commandSet.Notify(context.Background(), "3", "unknown", run.NewParametersFromTuples("value", 4))
+	assert.Equal(t, []int{1, 2, 3}, listener.values)
+
+	records, err := journal.Read(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, records, 3)
+	assert.Equal(t, "created", records[0].Event)
+	assert.Equal(t, "1", records[0].CorrelationId)
+	assert.False(t, records[0].Time.Before(start.Add(-time.Second)))
+
+	// Replay into command set listeners
+	listener.events = nil
+	listener.values = nil
+	count, err := commandSet.ReplayEvents(context.Background(), &commands.EventFilter{Event: "created"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"created:1", "created:2"}, listener.events)
+	assert.Equal(t, []int{1, 2}, listener.values)
+
+	// Replayed events are not recorded again
+	records, _ = journal.Read(context.Background(), nil)
+	assert.Len(t, records, 3)
+
+	// Replay into custom listeners
+	projection := &journalListener{}
+	count, err = journal.Replay(context.Background(), &commands.EventFilter{CorrelationId: "2"}, projection)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"created:2", "deleted:2"}, projection.events)
+
+	// Filter by time range
+	records, _ = journal.Read(context.Background(), &commands.EventFilter{ToTime: start.Add(-time.Hour)})
+	assert.Len(t, records, 0)
+	records, _ = journal.Read(context.Background(), &commands.EventFilter{FromTime: start.Add(-time.Second)})
+	assert.Len(t, records, 3)
+}
+
+func TestMemoryEventJournal(t *testing.T) {
+	testEventJournal(t, commands.NewMemoryEventStore(0))
+
+	store := commands.NewMemoryEventStore(2)
+	for i := 0; i < 3; i++ {
+		record, _ := commands.NewEventRecord("event", "123", run.NewParametersFromTuples("value", i))
+		store.Append(context.Background(), record)
+	}
+	records, _ := store.Read(context.Background(), nil)
+	assert.Len(t, records, 2)
+	args, _ := records[0].GetArgs()
+	assert.Equal(t, 1, args.GetAsInteger("value"))
+}
+
+func TestFileEventJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	store := commands.NewFileEventStore(path)
+	defer store.Close(context.Background(), "")
+	records, err := store.Read(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, records, 0)
+
+	testEventJournal(t, store)
+
+	// Records are kept between store instances
+	records, err = commands.NewFileEventStore(path).Read(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, records, 3)
+
+	// Corrupted journals are reported
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	file.WriteString("not a json\n")
+	file.Close()
+	_, err = store.Read(context.Background(), nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "JOURNAL_READ_FAILED", err.(*cerrors.ApplicationError).Code)
+}
+
+func TestFileEventStoreTornRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	store := commands.NewFileEventStore(path)
+	for i := 0; i < 2; i++ {
+		record, _ := commands.NewEventRecord("event", "123", run.NewParametersFromTuples("value", i))
+		assert.Nil(t, store.Append(context.Background(), record))
+	}
+	store.Close(context.Background(), "")
+
+	// Simulate a crash in the middle of an append
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	file.WriteString(`{"id":"3","event":"ev`)
+	file.Close()
+
+	store = commands.NewFileEventStore(path)
+	defer store.Close(context.Background(), "")
+	records, err := store.Read(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+
+	// The torn record is truncated before the next append
+	record, _ := commands.NewEventRecord("event", "123", run.NewParametersFromTuples("value", 2))
+	assert.Nil(t, store.Append(context.Background(), record))
+	records, err = store.Read(context.Background(), nil)
+	assert.Nil(t, err)
+	if assert.Len(t, records, 3) {
+		args, _ := records[2].GetArgs()
+		assert.Equal(t, 2, args.GetAsInteger("value"))
+	}
+}
+
+func TestFileEventStoreConcurrentReads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	store := commands.NewFileEventStore(path).WithSync(false)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			record, _ := commands.NewEventRecord("event", "123", run.NewParametersFromTuples("value", i))
+			assert.Nil(t, store.Append(context.Background(), record))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 20; i++ {
+			_, err := store.Read(context.Background(), nil)
+			assert.Nil(t, err)
+		}
+	}()
+	wg.Wait()
+
+	// The file is reopened after close
+	assert.Nil(t, store.Close(context.Background(), ""))
+	record, _ := commands.NewEventRecord("event", "123", nil)
+	assert.Nil(t, store.Append(context.Background(), record))
+	assert.Nil(t, store.Close(context.Background(), ""))
+
+	records, err := store.Read(context.Background(), nil)
+	assert.Nil(t, err)
+	assert.Len(t, records, 101)
+}
+
+func TestReplayWithoutJournal(t *testing.T) {
+	_, err := commands.NewCommandSet().ReplayEvents(context.Background(), nil)
+	assert.NotNil(t, err)
+}
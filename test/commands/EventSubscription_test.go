@@ -0,0 +1,88 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventNameMatchers(t *testing.T) {
+	matcher := commands.GlobEventMatcher("order.*")
+	assert.True(t, matcher("order.created"))
+	assert.True(t, matcher("order.item.added"))
+	assert.False(t, matcher("orders"))
+	assert.False(t, matcher("customer.created"))
+
+	matcher = commands.GlobEventMatcher("order.?")
+	assert.True(t, matcher("order.a"))
+	assert.False(t, matcher("order.ab"))
+
+	matcher, err := commands.RegexEventMatcher("order\\.(created|deleted)")
+	assert.Nil(t, err)
+	assert.True(t, matcher("order.created"))
+	assert.False(t, matcher("order.created.v2"))
+
+	_, err = commands.RegexEventMatcher("order.(")
+	assert.NotNil(t, err)
+}
+
+func TestSubscribeGlob(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddEvent(commands.NewEvent("order.created"))
+	commandSet.AddEvent(commands.NewEvent("customer.created"))
+
+	listener := &journalListener{}
+	subscription := commandSet.SubscribeGlob("order.*", nil, listener)
+
+	// Events added after subscription are covered
+	commandSet.AddEvent(commands.NewEvent("order.deleted"))
+
+	commandSet.Notify(context.Background(), "123", "order.created", run.NewParametersFromTuples("value", 1))
+	commandSet.Notify(context.Background(), "123", "customer.created", run.NewParametersFromTuples("value", 2))
+	commandSet.Notify(context.Background(), "123", "order.deleted", run.NewParametersFromTuples("value", 3))
+	assert.Equal(t, []string{"order.created:123", "order.deleted:123"}, listener.events)
+
+	subscription.Unsubscribe()
+	commandSet.AddEvent(commands.NewEvent("order.updated"))
+
+	commandSet.Notify(context.Background(), "123", "order.created", run.NewParametersFromTuples("value", 4))
+	commandSet.Notify(context.Background(), "123", "order.updated", run.NewParametersFromTuples("value", 5))
+	assert.Equal(t, []int{1, 3}, listener.values)
+}
+
+func TestSubscribeRegexWithPredicate(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddEvent(commands.NewEvent("order.created"))
+
+	listener := &journalListener{}
+	subscription, err := commandSet.SubscribeRegex("order\\..+",
+		func(args *run.Parameters) bool {
+			return args.GetAsInteger("value") > 10
+		},
+		listener)
+	assert.Nil(t, err)
+	assert.True(t, subscription.Matches("order.created"))
+
+	commandSet.Notify(context.Background(), "123", "order.created", run.NewParametersFromTuples("value", 5))
+	commandSet.Notify(context.Background(), "123", "order.created", run.NewParametersFromTuples("value", 15))
+	assert.Equal(t, []int{15}, listener.values)
+
+	_, err = commandSet.SubscribeRegex("order.(", nil, listener)
+	assert.NotNil(t, err)
+}
+
+func TestSubscribeAllEvents(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	listener := &journalListener{}
+	commandSet.Subscribe(nil, nil, listener)
+
+	commandSet.AddEvent(commands.NewEvent("event1"))
+	commandSet.AddEvent(commands.NewEvent("event2"))
+
+	commandSet.Notify(context.Background(), "123", "event1", run.NewParametersFromTuples("value", 1))
+	commandSet.Notify(context.Background(), "123", "event2", run.NewParametersFromTuples("value", 2))
+	assert.Equal(t, []string{"event1:123", "event2:123"}, listener.events)
+}
@@ -0,0 +1,118 @@
+package test_commands
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type itemStore struct {
+	mtx   sync.Mutex
+	items map[string]bool
+}
+
+func newBatchCommandSet(store *itemStore) *commands.CommandSet {
+	schema := validate.NewObjectSchema().WithRequiredProperty("id", convert.String)
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(commands.NewCompensableCommand("create", schema,
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			store.mtx.Lock()
+			defer store.mtx.Unlock()
+
+			id := args.GetAsString("id")
+			if store.items[id] {
+				return nil, cerrors.NewConflictError(correlationId, "DUPLICATE", "Item already exists")
+			}
+			store.items[id] = true
+			return id, nil
+		},
+		func(ctx context.Context, correlationId string, args *run.Parameters, result any) error {
+			store.mtx.Lock()
+			defer store.mtx.Unlock()
+
+			delete(store.items, result.(string))
+			return nil
+		}))
+	commandSet.AddCommand(commands.NewCommand("echo", nil, commandExec))
+	commandSet.AddInterceptor(&passInterceptor{})
+	return commandSet
+}
+
+func TestExecuteBatchValidation(t *testing.T) {
+	store := &itemStore{items: map[string]bool{}}
+	commandSet := newBatchCommandSet(store)
+
+	results, err := commandSet.ExecuteBatch(context.Background(), "123", []*commands.BatchCommand{
+		commands.NewBatchCommand("create", run.NewParametersFromTuples("id", "1")),
+		commands.NewBatchCommand("create", run.NewEmptyParameters()),
+		commands.NewBatchCommand("unknown", nil),
+	}, commands.BatchSequential)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "BATCH_INVALID", err.(*cerrors.ApplicationError).Code)
+	assert.Len(t, err.(*cerrors.ApplicationError).Errors(), 2)
+	assert.Nil(t, results[0].Err)
+	assert.NotNil(t, results[1].Err)
+	assert.NotNil(t, results[2].Err)
+	assert.False(t, results[0].Executed)
+	assert.Len(t, store.items, 0)
+}
+
+func TestExecuteBatchSequentialAndParallel(t *testing.T) {
+	for _, mode := range []commands.BatchMode{commands.BatchSequential, commands.BatchParallel} {
+		store := &itemStore{items: map[string]bool{"2": true}}
+		commandSet := newBatchCommandSet(store)
+
+		results, err := commandSet.ExecuteBatch(context.Background(), "123", []*commands.BatchCommand{
+			commands.NewBatchCommand("create", run.NewParametersFromTuples("id", "1")),
+			commands.NewBatchCommand("create", run.NewParametersFromTuples("id", "2")),
+			commands.NewBatchCommand("create", run.NewParametersFromTuples("id", "3")),
+		}, mode)
+
+		assert.NotNil(t, err)
+		assert.Equal(t, "BATCH_FAILED", err.(*cerrors.ApplicationError).Code)
+		assert.Equal(t, "1", results[0].Result)
+		assert.Equal(t, "DUPLICATE", results[1].Err.(*cerrors.ApplicationError).Code)
+		assert.Equal(t, "3", results[2].Result)
+		assert.True(t, results[2].Executed)
+		assert.Len(t, store.items, 3)
+	}
+}
+
+func TestExecuteBatchAllOrNothing(t *testing.T) {
+	store := &itemStore{items: map[string]bool{"3": true}}
+	commandSet := newBatchCommandSet(store)
+
+	results, err := commandSet.ExecuteBatch(context.Background(), "123", []*commands.BatchCommand{
+		commands.NewBatchCommand("create", run.NewParametersFromTuples("id", "1")),
+		commands.NewBatchCommand("echo", nil),
+		commands.NewBatchCommand("create", run.NewParametersFromTuples("id", "3")),
+		commands.NewBatchCommand("create", run.NewParametersFromTuples("id", "4")),
+	}, commands.BatchAllOrNothing)
+
+	assert.NotNil(t, err)
+	assert.True(t, cerrors.Is(err, cerrors.NewConflictError("", "DUPLICATE", "")))
+	assert.True(t, results[0].Compensated)
+	assert.False(t, results[1].Compensated)
+	assert.False(t, results[2].Compensated)
+	assert.False(t, results[3].Executed)
+	assert.Equal(t, "BATCH_ABORTED", results[3].Err.(*cerrors.ApplicationError).Code)
+	assert.Equal(t, map[string]bool{"3": true}, store.items)
+
+	results, err = commandSet.ExecuteBatch(context.Background(), "123", []*commands.BatchCommand{
+		commands.NewBatchCommand("create", run.NewParametersFromTuples("id", "1")),
+		commands.NewBatchCommand("create", run.NewParametersFromTuples("id", "2")),
+	}, commands.BatchAllOrNothing)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "2", results[1].Result)
+	assert.Len(t, store.items, 3)
+}
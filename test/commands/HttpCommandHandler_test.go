@@ -0,0 +1,174 @@
+package test_commands
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type httpCommandable struct {
+	commandSet *commands.CommandSet
+}
+
+func (c *httpCommandable) GetCommandSet() *commands.CommandSet {
+	return c.commandSet
+}
+
+func newHttpCommandServer() *httptest.Server {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(newSumCommand())
+	commandSet.AddCommand(commands.NewCommand(
+		"get_item",
+		validate.NewObjectSchema().WithRequiredProperty("id", convert.String),
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			id := args.GetAsString("id")
+			if id == "0" {
+				return nil, errors.NewNotFoundError(correlationId, "ITEM_NOT_FOUND", "Item not found").
+					WithDetails("id", id)
+			}
+			return map[string]any{"id": id, "correlation_id": correlationId}, nil
+		},
+	))
+	commandSet.AddCommand(commands.NewCommand("noop", nil,
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			return nil, nil
+		},
+	))
+
+	handler := commands.NewHttpCommandHandler(&httpCommandable{commandSet: commandSet}).WithBasePath("/v1/items")
+	return httptest.NewServer(handler)
+}
+
+func postJson(t *testing.T, url string, body string) (int, map[string]any) {
+	response, err := http.Post(url, "application/json", strings.NewReader(body))
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	result := map[string]any{}
+	json.NewDecoder(response.Body).Decode(&result)
+	return response.StatusCode, result
+}
+
+func TestHttpCommandHandler(t *testing.T) {
+	server := newHttpCommandServer()
+	defer server.Close()
+
+	status, result := postJson(t, server.URL+"/v1/items/get_item?correlation_id=123", `{"id": "5"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "5", result["id"])
+	assert.Equal(t, "123", result["correlation_id"])
+
+	status, result = postJson(t, server.URL+"/v1/items/get_item", `{"id": "0"}`)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "ITEM_NOT_FOUND", result["code"])
+
+	status, result = postJson(t, server.URL+"/v1/items/get_item", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_DATA", result["code"])
+
+	status, result = postJson(t, server.URL+"/v1/items/unknown", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "CMD_NOT_FOUND", result["code"])
+
+	status, _ = postJson(t, server.URL+"/v1/items/noop", ``)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	response, err := http.Get(server.URL + "/v1/items/get_item")
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, response.StatusCode)
+}
+
+func TestHttpCommandHandlerJsonRpc(t *testing.T) {
+	server := newHttpCommandServer()
+	defer server.Close()
+
+	status, result := postJson(t, server.URL+"/v1/items/",
+		`{"jsonrpc": "2.0", "method": "get_item", "params": {"id": "5"}, "id": 1}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, float64(1), result["id"])
+	assert.Equal(t, "5", result["result"].(map[string]any)["id"])
+
+	_, result = postJson(t, server.URL+"/v1/items/",
+		`{"jsonrpc": "2.0", "method": "unknown", "id": "a"}`)
+	assert.Equal(t, "a", result["id"])
+	assert.Equal(t, float64(commands.JsonRpcMethodNotFound), result["error"].(map[string]any)["code"])
+
+	_, result = postJson(t, server.URL+"/v1/items/", `{"jsonrpc": "2.0", "method": `)
+	assert.Equal(t, float64(commands.JsonRpcParseError), result["error"].(map[string]any)["code"])
+
+	response, err := http.Post(server.URL+"/v1/items/", "application/json", strings.NewReader(`[
+		{"jsonrpc": "2.0", "method": "get_item", "params": {"id": "0"}, "id": 1},
+		{"jsonrpc": "2.0", "method": "get_item", "params": {"id": "1"}},
+		{"jsonrpc": "2.0", "method": "noop", "id": 2}
+	]`))
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	responses := []map[string]any{}
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&responses))
+	assert.Len(t, responses, 2)
+	data := responses[0]["error"].(map[string]any)["data"].(map[string]any)
+	assert.Equal(t, "ITEM_NOT_FOUND", data["code"])
+	assert.Contains(t, responses[1], "result")
+	assert.Nil(t, responses[1]["result"])
+}
+
+func TestRemoteCommand(t *testing.T) {
+	server := newHttpCommandServer()
+	defer server.Close()
+
+	command := commands.NewRemoteCommand("get_item", server.URL+"/v1/items")
+	result, err := command.Execute(context.Background(), "123", run.NewParametersFromTuples("id", "5"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]any{"id": "5", "correlation_id": "123"}, result)
+
+	_, err = command.Execute(context.Background(), "123", run.NewParametersFromTuples("id", "0"))
+	var appErr *errors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "ITEM_NOT_FOUND", appErr.Code)
+	assert.Equal(t, errors.NotFound, appErr.Category)
+	assert.Equal(t, "0", appErr.Details["id"])
+
+	result, err = commands.NewRemoteCommand("noop", server.URL+"/v1/items").
+		Execute(context.Background(), "", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+
+	// Remote commands can be registered in a local command set
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(command.WithSchema(validate.NewObjectSchema().WithRequiredProperty("id", convert.String)))
+	_, err = commandSet.Execute(context.Background(), "123", "get_item", run.NewEmptyParameters())
+	assert.NotNil(t, err)
+
+	_, err = commands.NewRemoteCommand("get_item", "http://127.0.0.1:1").
+		Execute(context.Background(), "123", run.NewParametersFromTuples("id", "5"))
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "CONNECT_FAILED", appErr.Code)
+	assert.Equal(t, http.StatusServiceUnavailable, appErr.Status)
+}
+
+func TestHttpCommandHandlerMaxBodySize(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(newSumCommand())
+	handler := commands.NewHttpCommandHandler(&httpCommandable{commandSet: commandSet}).WithMaxBodySize(32)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	status, result := postJson(t, server.URL+"/sum", `{"a": 1, "b": 2}`)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, result = postJson(t, server.URL+"/sum", `{"a": 1, "b": 2, "items": [{"value": 1}, {"value": 2}]}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, status)
+	assert.Equal(t, "BODY_TOO_LARGE", result["code"])
+}
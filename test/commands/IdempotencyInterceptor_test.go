@@ -0,0 +1,213 @@
+package test_commands
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyInterceptor(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewIdempotencyInterceptor(commands.NewMemoryIdempotencyCache(100, 0)))
+	command, calls := newFailingCommand("create_order", 0, nil)
+	commandSet.AddCommand(command)
+
+	args := run.NewParametersFromTuples("idempotency_key", "key1", "total", 10)
+	result, err := commandSet.Execute(context.Background(), "123", "create_order", args)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result)
+
+	// Duplicates get the stored result
+	result, err = commandSet.Execute(context.Background(), "123", "create_order", args)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result)
+	assert.Equal(t, 1, *calls)
+
+	// Key reuse with different arguments
+	_, err = commandSet.Execute(context.Background(), "123", "create_order",
+		run.NewParametersFromTuples("idempotency_key", "key1", "total", 20))
+	var appErr *errors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "IDEMPOTENCY_KEY_REUSED", appErr.Code)
+	assert.Equal(t, errors.Conflict, appErr.Category)
+
+	// Keys from context
+	ctx := run.WithIdempotencyKey(context.Background(), "key2")
+	result, _ = commandSet.Execute(ctx, "123", "create_order", run.NewParametersFromTuples("total", 10))
+	assert.Equal(t, 2, result)
+	result, _ = commandSet.Execute(ctx, "123", "create_order", run.NewParametersFromTuples("total", 10))
+	assert.Equal(t, 2, result)
+
+	// Keys are scoped by tenants
+	result, _ = commandSet.Execute(run.WithTenant(ctx, "tenant1"), "123", "create_order",
+		run.NewParametersFromTuples("total", 10))
+	assert.Equal(t, 3, result)
+
+	// Keys are scoped by principals
+	result, _ = commandSet.Execute(run.WithPrincipal(ctx, run.NewPrincipal("user1")), "123", "create_order",
+		run.NewParametersFromTuples("total", 10))
+	assert.Equal(t, 4, result)
+	result, _ = commandSet.Execute(run.WithPrincipal(ctx, run.NewPrincipal("user2")), "123", "create_order",
+		run.NewParametersFromTuples("total", 10))
+	assert.Equal(t, 5, result)
+
+	// Separators in key parts do not collide
+	result, _ = commandSet.Execute(run.WithIdempotencyKey(run.WithTenant(context.Background(), "a:b"), "c"), "123",
+		"create_order", run.NewParametersFromTuples("total", 10))
+	assert.Equal(t, 6, result)
+	result, _ = commandSet.Execute(run.WithIdempotencyKey(run.WithTenant(context.Background(), "a"), "b:c"), "123",
+		"create_order", run.NewParametersFromTuples("total", 10))
+	assert.Equal(t, 7, result)
+
+	// Calls without keys are always executed
+	result, _ = commandSet.Execute(context.Background(), "123", "create_order", run.NewParametersFromTuples("total", 10))
+	assert.Equal(t, 8, result)
+}
+
+func TestIdempotencyInterceptorCopiesResults(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewIdempotencyInterceptor(commands.NewMemoryIdempotencyCache(100, 0)))
+	commandSet.AddCommand(commands.NewCommand("get_order", nil,
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			return map[string]any{"items": []any{"a"}}, nil
+		}))
+
+	ctx := run.WithIdempotencyKey(context.Background(), "key1")
+	result, _ := commandSet.Execute(ctx, "123", "get_order", nil)
+	order := result.(map[string]any)
+	order["status"] = "changed"
+	order["items"].([]any)[0] = "b"
+
+	result, _ = commandSet.Execute(ctx, "123", "get_order", nil)
+	assert.Equal(t, map[string]any{"items": []any{"a"}}, result)
+	result.(map[string]any)["status"] = "changed"
+
+	result, _ = commandSet.Execute(ctx, "123", "get_order", nil)
+	assert.Equal(t, map[string]any{"items": []any{"a"}}, result)
+}
+
+func TestIdempotencyInterceptorErrors(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewIdempotencyInterceptor(commands.NewMemoryIdempotencyCache(100, 0)))
+
+	notFound, notFoundCalls := newFailingCommand("not_found", 1,
+		errors.NewNotFoundError("", "ITEM_NOT_FOUND", "Item not found").WithDetails("id", "1"))
+	unavailable, unavailableCalls := newFailingCommand("unavailable", 1,
+		errors.NewConnectionError("", "CONNECT_FAILED", "Connection failed"))
+	commandSet.AddCommand(notFound)
+	commandSet.AddCommand(unavailable)
+
+	args := run.NewParametersFromTuples("idempotency_key", "key1")
+
+	// Application errors are replayed
+	for i := 0; i < 2; i++ {
+		_, err := commandSet.Execute(context.Background(), "123", "not_found", args)
+		var appErr *errors.ApplicationError
+		assert.True(t, errors.As(err, &appErr))
+		assert.Equal(t, "ITEM_NOT_FOUND", appErr.Code)
+		assert.Equal(t, errors.NotFound, appErr.Category)
+		assert.Equal(t, "1", appErr.Details["id"])
+	}
+	assert.Equal(t, 1, *notFoundCalls)
+
+	// Retryable errors are not stored
+	_, err := commandSet.Execute(context.Background(), "123", "unavailable", args)
+	assert.NotNil(t, err)
+	result, err := commandSet.Execute(context.Background(), "123", "unavailable", args)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result)
+	assert.Equal(t, 2, *unavailableCalls)
+}
+
+func TestIdempotencyInterceptorReplayedStatus(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewIdempotencyInterceptor(commands.NewMemoryIdempotencyCache(100, 0)))
+
+	failures := map[string]*errors.ApplicationError{
+		"denied":      errors.NewUnauthorizedError("", "ACCESS_DENIED", "Access denied").WithStatus(403),
+		"throttled":   errors.NewBadRequestError("", "TOO_MANY_REQUESTS", "Too many requests").WithStatus(429),
+		"unavailable": errors.NewConnectionError("", "UNAVAILABLE", "Unavailable").WithStatus(503).WithRetryable(false),
+	}
+	for name, failure := range failures {
+		command, _ := newFailingCommand(name, 1, failure)
+		commandSet.AddCommand(command)
+	}
+
+	args := run.NewParametersFromTuples("idempotency_key", "key1")
+	for name, failure := range failures {
+		_, err := commandSet.Execute(context.Background(), "123", name, args)
+		original := err.(*errors.ApplicationError)
+		assert.Equal(t, failure.Status, original.Status, name)
+
+		_, err = commandSet.Execute(context.Background(), "123", name, args)
+		var replayed *errors.ApplicationError
+		if assert.True(t, errors.As(err, &replayed), name) {
+			assert.Equal(t, original.Code, replayed.Code, name)
+			assert.Equal(t, original.Category, replayed.Category, name)
+			assert.Equal(t, original.Status, replayed.Status, name)
+		}
+	}
+}
+
+func TestIdempotencyInterceptorConcurrency(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(commands.NewIdempotencyInterceptor(commands.NewMemoryIdempotencyCache(100, 0)))
+
+	mtx := sync.Mutex{}
+	calls := 0
+	commandSet.AddCommand(commands.NewCommand("create_order", nil,
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			time.Sleep(10 * time.Millisecond)
+			mtx.Lock()
+			defer mtx.Unlock()
+			calls++
+			return calls, nil
+		},
+	))
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := commandSet.Execute(context.Background(), "123", "create_order",
+				run.NewParametersFromTuples("idempotency_key", "key1"))
+			assert.Nil(t, err)
+			assert.Equal(t, 1, result)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemoryIdempotencyCache(t *testing.T) {
+	cache := commands.NewMemoryIdempotencyCache(2, 50)
+	ctx := context.Background()
+
+	cache.Put(ctx, "key1", &commands.IdempotencyRecord{Result: 1})
+	cache.Put(ctx, "key2", &commands.IdempotencyRecord{Result: 2})
+
+	// Reading key1 makes key2 the least recently used
+	record, _ := cache.Get(ctx, "key1")
+	assert.Equal(t, 1, record.Result)
+	cache.Put(ctx, "key3", &commands.IdempotencyRecord{Result: 3})
+
+	record, _ = cache.Get(ctx, "key2")
+	assert.Nil(t, record)
+	record, _ = cache.Get(ctx, "key3")
+	assert.Equal(t, 3, record.Result)
+
+	time.Sleep(100 * time.Millisecond)
+	record, _ = cache.Get(ctx, "key1")
+	assert.Nil(t, record)
+
+	// Expired records are purged on writes
+	cache.Put(ctx, "key4", &commands.IdempotencyRecord{Result: 4})
+	assert.Equal(t, 1, cache.Size())
+}
@@ -0,0 +1,43 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitInterceptor(t *testing.T) {
+	interceptor := commands.NewRateLimitInterceptor(0)
+	interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+		"commands.limited.rate_limit.rate", 1,
+		"commands.limited.rate_limit.burst", 2,
+	))
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+	commandSet.AddCommand(newSleepCommand("limited", 0))
+	commandSet.AddCommand(newSleepCommand("unlimited", 0))
+
+	for i := 0; i < 2; i++ {
+		_, err := commandSet.Execute(context.Background(), "123", "limited", nil)
+		assert.Nil(t, err)
+	}
+
+	_, err := commandSet.Execute(context.Background(), "123", "limited", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "RATE_LIMIT_EXCEEDED", err.(*cerrors.ApplicationError).Code)
+	assert.Equal(t, 429, err.(*cerrors.ApplicationError).Status)
+	assert.True(t, cerrors.IsRetryable(err))
+	retryAfter, ok := cerrors.GetRetryAfter(err)
+	assert.True(t, ok)
+	assert.True(t, retryAfter > 0 && retryAfter <= 1000)
+
+	for i := 0; i < 10; i++ {
+		_, err := commandSet.Execute(context.Background(), "123", "unlimited", nil)
+		assert.Nil(t, err)
+	}
+}
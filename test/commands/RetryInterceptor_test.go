@@ -0,0 +1,64 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFailingCommand(name string, failures int, err error) (commands.ICommand, *int) {
+	calls := 0
+	command := commands.NewCommand(name, nil,
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			calls++
+			if calls <= failures {
+				return nil, err
+			}
+			return calls, nil
+		})
+	return command, &calls
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	interceptor := commands.NewRetryInterceptor()
+	interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+		"retry.attempts", 3,
+		"retry.initial_delay", 1,
+		"commands.once.retry.attempts", 1,
+	))
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+
+	retryable := cerrors.NewConnectionError("123", "NO_CONNECTION", "No connection")
+
+	command, calls := newFailingCommand("command", 2, retryable)
+	commandSet.AddCommand(command)
+	result, err := commandSet.Execute(context.Background(), "123", "command", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result)
+	assert.Equal(t, 3, *calls)
+
+	command, calls = newFailingCommand("exhausted", 5, retryable)
+	commandSet.AddCommand(command)
+	_, err = commandSet.Execute(context.Background(), "123", "exhausted", nil)
+	assert.Equal(t, retryable, err)
+	assert.Equal(t, 3, *calls)
+
+	command, calls = newFailingCommand("once", 5, retryable)
+	commandSet.AddCommand(command)
+	_, err = commandSet.Execute(context.Background(), "123", "once", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, *calls)
+
+	command, calls = newFailingCommand("bad_request", 5, cerrors.NewBadRequestError("123", "BAD", "Bad request"))
+	commandSet.AddCommand(command)
+	_, err = commandSet.Execute(context.Background(), "123", "bad_request", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, *calls)
+}
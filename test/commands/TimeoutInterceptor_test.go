@@ -0,0 +1,47 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSleepCommand(name string, delay time.Duration) commands.ICommand {
+	return commands.NewCommand(name, nil,
+		func(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+			time.Sleep(delay)
+			return "done", nil
+		})
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	interceptor := commands.NewTimeoutInterceptor(0)
+	interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+		"timeout", 1000,
+		"commands.slow.timeout", 10,
+	))
+	assert.Equal(t, int64(1000), interceptor.GetTimeout("fast"))
+	assert.Equal(t, int64(10), interceptor.GetTimeout("slow"))
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+	commandSet.AddCommand(newSleepCommand("fast", 20*time.Millisecond))
+	commandSet.AddCommand(newSleepCommand("slow", 20*time.Millisecond))
+
+	result, err := commandSet.Execute(context.Background(), "123", "fast", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "done", result)
+
+	_, err = commandSet.Execute(context.Background(), "123", "slow", nil)
+	assert.NotNil(t, err)
+	appErr := err.(*cerrors.ApplicationError)
+	assert.Equal(t, "TIMEOUT", appErr.Code)
+	assert.Equal(t, "slow", appErr.Details["command"])
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
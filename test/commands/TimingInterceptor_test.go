@@ -0,0 +1,42 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/config"
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimingInterceptor(t *testing.T) {
+	outcomes := map[string]error{}
+	durations := map[string]time.Duration{}
+
+	interceptor := commands.NewTimingInterceptor(func(ctx context.Context, correlationId string,
+		command string, duration time.Duration, err error) {
+		outcomes[command] = err
+		durations[command] = duration
+	})
+	interceptor.Configure(context.Background(), config.NewConfigParamsFromTuples(
+		"commands.hidden.timing.enabled", false,
+	))
+
+	commandSet := commands.NewCommandSet()
+	commandSet.AddInterceptor(interceptor)
+	commandSet.AddCommand(newSleepCommand("sleep", 10*time.Millisecond))
+	commandSet.AddCommand(newSleepCommand("hidden", 0))
+	failing, _ := newFailingCommand("failing", 1, cerrors.NewInternalError("123", "FAILED", "Failed"))
+	commandSet.AddCommand(failing)
+
+	commandSet.Execute(context.Background(), "123", "sleep", nil)
+	commandSet.Execute(context.Background(), "123", "hidden", nil)
+	commandSet.Execute(context.Background(), "123", "failing", nil)
+
+	assert.Len(t, outcomes, 2)
+	assert.Nil(t, outcomes["sleep"])
+	assert.True(t, durations["sleep"] >= 10*time.Millisecond)
+	assert.NotNil(t, outcomes["failing"])
+}
@@ -0,0 +1,124 @@
+package test_commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/commands"
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type sumItem struct {
+	Value float64 `json:"value"`
+}
+
+type sumArgs struct {
+	A     int               `json:"a"`
+	B     *int              `json:"b"`
+	Items []sumItem         `json:"items,omitempty"`
+	Tags  map[string]string `json:"tags,omitempty"`
+}
+
+func newSumCommand() *commands.TypedCommand[sumArgs, float64] {
+	return commands.NewTypedCommand("sum", nil,
+		func(ctx context.Context, correlationId string, args sumArgs) (float64, error) {
+			result := float64(args.A)
+			if args.B != nil {
+				result += float64(*args.B)
+			}
+			for _, item := range args.Items {
+				result += item.Value
+			}
+			return result, nil
+		})
+}
+
+func TestTypedCommandExecute(t *testing.T) {
+	command := newSumCommand()
+	assert.Equal(t, "sum", command.Name())
+	assert.NotNil(t, command.GetSchema())
+
+	result, err := command.Execute(context.Background(), "123", run.NewParametersFromTuples(
+		"a", 1,
+		"B", 2,
+		"items", []any{map[string]any{"value": 3.5}, map[string]any{"value": 0.5}},
+	))
+	assert.Nil(t, err)
+	assert.Equal(t, 7.0, result)
+
+	result, err = command.ExecuteTyped(context.Background(), "123", sumArgs{A: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, 1.0, result)
+}
+
+func TestTypedCommandValidation(t *testing.T) {
+	command := newSumCommand()
+
+	results := command.Validate(run.NewParametersFromTuples("b", 1))
+	assert.Len(t, results, 0)
+
+	results = command.Validate(run.NewParametersFromTuples("a", "ABC"))
+	assert.Len(t, results, 1)
+
+	_, err := command.Execute(context.Background(), "123", run.NewParametersFromTuples("a", "ABC"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "INVALID_DATA", err.(*cerrors.ApplicationError).Code)
+}
+
+func TestTypedCommandInCommandSet(t *testing.T) {
+	commandSet := commands.NewCommandSet()
+	commandSet.AddCommand(newSumCommand())
+
+	result, err := commandSet.Execute(context.Background(), "123", "sum", run.NewParametersFromTuples("a", 2, "b", 2))
+	assert.Nil(t, err)
+	assert.Equal(t, 4.0, result)
+}
+
+func TestBindParameters(t *testing.T) {
+	args, err := commands.BindParameters[sumArgs]("123", run.NewParametersFromTuples(
+		"a", "5",
+		"tags", map[string]any{"key": "value"},
+	))
+	assert.Nil(t, err)
+	assert.Equal(t, 5, args.A)
+	assert.Nil(t, args.B)
+	assert.Equal(t, map[string]string{"key": "value"}, args.Tags)
+
+	_, err = commands.BindParameters[sumArgs]("123", run.NewParametersFromTuples("items", "ABC"))
+	assert.NotNil(t, err)
+	appErr := err.(*cerrors.ApplicationError)
+	assert.Equal(t, "INVALID_ARGS", appErr.Code)
+	assert.Equal(t, "items.0", appErr.Details["property"])
+}
+
+type bindRangeArgs struct {
+	Small  int8       `json:"small"`
+	Count  uint       `json:"count"`
+	Ratio  float32    `json:"ratio"`
+	Coords [2]float64 `json:"coords"`
+}
+
+func TestBindParametersArrayAndRange(t *testing.T) {
+	args, err := commands.BindParameters[bindRangeArgs]("123", run.NewParametersFromTuples(
+		"small", 100,
+		"count", "7",
+		"coords", []any{1.5, "2.5"},
+	))
+	assert.Nil(t, err)
+	assert.Equal(t, int8(100), args.Small)
+	assert.Equal(t, uint(7), args.Count)
+	assert.Equal(t, [2]float64{1.5, 2.5}, args.Coords)
+
+	_, err = commands.BindParameters[bindRangeArgs]("123", run.NewParametersFromTuples("coords", []any{1, 2, 3}))
+	assert.NotNil(t, err)
+
+	for _, tuple := range [][]any{{"small", 300}, {"count", -1}, {"ratio", 1e300}} {
+		_, err = commands.BindParameters[bindRangeArgs]("123", run.NewParametersFromTuples(tuple...))
+		assert.NotNil(t, err)
+		appErr := err.(*cerrors.ApplicationError)
+		assert.Equal(t, "INVALID_ARGS", appErr.Code)
+		assert.Equal(t, tuple[0], appErr.Details["property"])
+	}
+}
@@ -0,0 +1,71 @@
+package test_errors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"testing"
+
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateErrorTraversal(t *testing.T) {
+	pathErr := &fs.PathError{Op: "open", Path: "test.txt", Err: fs.ErrNotExist}
+	err := cerrors.NewAggregateError("123", "CLOSE_FAILED", "Failed to close components").
+		AddError(context.DeadlineExceeded).
+		AddComponentError("db", pathErr).
+		AddError(nil)
+
+	assert.Len(t, err.Errors(), 2)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+	assert.False(t, errors.Is(err, context.Canceled))
+
+	var target *fs.PathError
+	assert.True(t, errors.As(err, &target))
+	assert.Equal(t, "test.txt", target.Path)
+	assert.Equal(t, cerrors.Unknown, err.Category)
+}
+
+func TestAggregateErrorCategory(t *testing.T) {
+	err := cerrors.NewAggregateError("123", "FAILED", "Failed",
+		cerrors.NewNotFoundError("123", "NOT_FOUND_1", "Not found"),
+		cerrors.NewNotFoundError("123", "NOT_FOUND_2", "Not found"),
+	)
+	assert.Equal(t, cerrors.NotFound, err.Category)
+	assert.Equal(t, 404, err.Status)
+	assert.True(t, errors.Is(err, cerrors.NewNotFoundError("", "NOT_FOUND_2", "")))
+
+	err.AddError(cerrors.NewConflictError("123", "CONFLICT", "Conflict"))
+	assert.Equal(t, cerrors.Unknown, err.Category)
+	assert.Equal(t, 500, err.Status)
+
+	// Ordinary errors keep their category
+	err = cerrors.NewNotFoundError("123", "NOT_FOUND", "Not found").
+		AddError(cerrors.NewConflictError("123", "CONFLICT", "Conflict"))
+	assert.Equal(t, cerrors.NotFound, err.Category)
+	assert.Equal(t, 404, err.Status)
+	assert.Len(t, err.Errors(), 1)
+}
+
+func TestAggregateErrorDescription(t *testing.T) {
+	err := cerrors.NewAggregateError("123", "OPEN_FAILED", "Failed to open components").
+		AddComponentError("db", cerrors.NewConnectionError("123", "NO_CONNECTION", "No connection")).
+		AddError(errors.New("Plain error"))
+
+	data, _ := json.Marshal(cerrors.ErrorDescriptionFactory.Create(err))
+	var d cerrors.ErrorDescription
+	json.Unmarshal(data, &d)
+
+	assert.Len(t, d.Errors, 2)
+	assert.Equal(t, cerrors.NoResponse, d.Errors[0].Category)
+	assert.Equal(t, "db", d.Errors[0].Details["component"])
+	assert.Equal(t, "Plain error", d.Errors[1].Message)
+
+	restored := cerrors.ApplicationErrorFactory.Create(&d)
+	assert.Equal(t, cerrors.Unknown, restored.Category)
+	assert.Len(t, restored.Errors(), 2)
+	assert.True(t, errors.Is(restored, cerrors.NewConnectionError("", "NO_CONNECTION", "")))
+}
@@ -24,7 +24,7 @@ func TestCreateFromUnknown(t *testing.T) {
 	assert.Equal(t, "123", err.CorrelationId)
 	assert.Equal(t, "CODE", err.Code)
 	assert.Equal(t, "Error message", err.Message)
-	assert.Equal(t, 500, err.Status)
+	assert.Equal(t, 321, err.Status)
 	assert.Equal(t, "Error cause", err.Cause)
 }
 
@@ -45,6 +45,6 @@ func TestCreateFromNotFound(t *testing.T) {
 	assert.Equal(t, "123", err.CorrelationId)
 	assert.Equal(t, "CODE", err.Code)
 	assert.Equal(t, "Error message", err.Message)
-	assert.Equal(t, 404, err.Status)
+	assert.Equal(t, 321, err.Status)
 	assert.Equal(t, "Error cause", err.Cause)
 }
@@ -1,7 +1,9 @@
 package test_errors
 
 import (
+	"context"
 	"errors"
+	"io/fs"
 	"testing"
 
 	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
@@ -34,3 +36,33 @@ func TestWithStatus(t *testing.T) {
 
 	assert.Equal(t, 300, err.Status)
 }
+
+func TestWrapKeepsCauseChain(t *testing.T) {
+	err := cerrors.NewConnectionError("123", "TIMEOUT", "Timeout").WithCause(context.DeadlineExceeded)
+
+	assert.Equal(t, context.DeadlineExceeded.Error(), err.Cause)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, context.DeadlineExceeded, errors.Unwrap(err))
+
+	pathErr := &fs.PathError{Op: "open", Path: "test.txt", Err: fs.ErrNotExist}
+	wrapped := cerrors.WrapError(pathErr, "Cannot open file")
+
+	var target *fs.PathError
+	assert.True(t, errors.As(wrapped, &target))
+	assert.Equal(t, "test.txt", target.Path)
+	assert.True(t, cerrors.Is(wrapped, fs.ErrNotExist))
+}
+
+func TestIsByCategoryAndCode(t *testing.T) {
+	err := cerrors.NewNotFoundError("123", "NOT_FOUND", "Object not found")
+	chained := cerrors.NewInternalError("123", "FAILED", "Failed").WithCause(err)
+
+	assert.True(t, errors.Is(chained, cerrors.NewNotFoundError("", "NOT_FOUND", "")))
+	assert.True(t, errors.Is(chained, &cerrors.ApplicationError{Category: cerrors.NotFound}))
+	assert.False(t, errors.Is(chained, cerrors.NewNotFoundError("", "OTHER_CODE", "")))
+	assert.False(t, errors.Is(chained, &cerrors.ApplicationError{}))
+
+	var appErr *cerrors.ApplicationError
+	assert.True(t, errors.As(chained, &appErr))
+	assert.Equal(t, "FAILED", appErr.Code)
+}
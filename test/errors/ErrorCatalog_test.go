@@ -0,0 +1,63 @@
+package test_errors
+
+import (
+	"encoding/json"
+	"testing"
+
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCatalogCreate(t *testing.T) {
+	cerrors.ErrorCatalog.Register("TEST_NOT_FOUND", cerrors.NotFound, 0, "Item {{id}} in {{ collection }} not found")
+
+	err := cerrors.ErrorCatalog.Create("123", "TEST_NOT_FOUND", map[string]any{"id": 5, "collection": "items"})
+	assert.Equal(t, cerrors.NotFound, err.Category)
+	assert.Equal(t, 404, err.Status)
+	assert.Equal(t, "TEST_NOT_FOUND", err.Code)
+	assert.Equal(t, "Item 5 in items not found", err.Message)
+
+	err = cerrors.ErrorCatalog.Create("123", "TEST_UNREGISTERED", nil)
+	assert.Equal(t, cerrors.Unknown, err.Category)
+
+	assert.Equal(t, "Value {{missing}}", cerrors.RenderMessageTemplate("Value {{missing}}", nil))
+}
+
+func TestErrorCatalogLocalization(t *testing.T) {
+	cerrors.ErrorCatalog.Register("TEST_CMD_NOT_FOUND", cerrors.BadRequest, 400, "Command {{command}} not found")
+	cerrors.ErrorCatalog.AddTranslation("TEST_CMD_NOT_FOUND", "de", "Befehl {{command}} nicht gefunden")
+	cerrors.ErrorCatalog.AddTranslation("TEST_CMD_NOT_FOUND", "pt_BR", "Comando {{command}} não encontrado")
+
+	details := map[string]any{"command": "get_data"}
+	message, ok := cerrors.ErrorCatalog.FormatMessage("TEST_CMD_NOT_FOUND", "de-AT", details)
+	assert.True(t, ok)
+	assert.Equal(t, "Befehl get_data nicht gefunden", message)
+
+	message, _ = cerrors.ErrorCatalog.FormatMessage("TEST_CMD_NOT_FOUND", "fr", details)
+	assert.Equal(t, "Command get_data not found", message)
+
+	err := cerrors.NewBadRequestError("123", "TEST_CMD_NOT_FOUND", "Original message").WithDetails("command", "get_data")
+	d := cerrors.ErrorDescriptionFactory.CreateLocalized(err, "pt-BR")
+	assert.Equal(t, "Comando get_data não encontrado", d.Message)
+
+	d = cerrors.ErrorDescriptionFactory.CreateLocalized(err, "fr")
+	assert.Equal(t, "Original message", d.Message)
+}
+
+func TestErrorCatalogCodes(t *testing.T) {
+	cerrors.ErrorCatalog.Register("TEST_CONFLICT", cerrors.Conflict, 0, "Conflict")
+
+	definition, ok := cerrors.ErrorCatalog.Get("TEST_CONFLICT")
+	assert.True(t, ok)
+	assert.Equal(t, 409, definition.Status)
+
+	codes := cerrors.ErrorCatalog.Codes()
+	assert.True(t, len(codes) > 0)
+	for i := 1; i < len(codes); i++ {
+		assert.True(t, codes[i-1].Code < codes[i].Code)
+	}
+
+	data, err := json.Marshal(codes)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"code":"TEST_CONFLICT"`)
+}
@@ -0,0 +1,91 @@
+package test_errors
+
+import (
+	"testing"
+
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandardCategories(t *testing.T) {
+	assert.True(t, cerrors.ErrorCategoryRegistry.Has(cerrors.NotFound))
+
+	status, ok := cerrors.ErrorCategoryRegistry.GetStatus(cerrors.Unauthorized)
+	assert.True(t, ok)
+	assert.Equal(t, 401, status)
+
+	err := cerrors.ErrorCategoryRegistry.Create(cerrors.Conflict, "123", "CODE", "Error message")
+	assert.Equal(t, cerrors.Conflict, err.Category)
+	assert.Equal(t, 409, err.Status)
+}
+
+func TestCustomCategoryRoundTrip(t *testing.T) {
+	cerrors.ErrorCategoryRegistry.Register("RateLimited", 429, nil)
+	defer cerrors.ErrorCategoryRegistry.Unregister("RateLimited")
+
+	cerrors.ErrorCategoryRegistry.Register("PaymentRequired", 402,
+		func(correlationId, code, message string) *cerrors.ApplicationError {
+			err := cerrors.NewBadRequestError(correlationId, code, message).WithStatus(402)
+			err.Category = "PaymentRequired"
+			return err
+		})
+	defer cerrors.ErrorCategoryRegistry.Unregister("PaymentRequired")
+
+	err := cerrors.ErrorCategoryRegistry.Create("RateLimited", "123", "TOO_MANY", "Too many requests")
+	assert.Equal(t, "RateLimited", err.Category)
+	assert.Equal(t, 429, err.Status)
+
+	d := cerrors.ErrorDescriptionFactory.Create(err)
+	assert.Equal(t, 429, d.Status)
+
+	d.Status = 0
+	restored := cerrors.ApplicationErrorFactory.Create(d)
+	assert.Equal(t, "RateLimited", restored.Category)
+	assert.Equal(t, 429, restored.Status)
+	assert.Equal(t, "TOO_MANY", restored.Code)
+
+	restored = cerrors.ApplicationErrorFactory.Create(&cerrors.ErrorDescription{
+		Category: "PaymentRequired",
+		Code:     "NO_FUNDS",
+		Message:  "Payment required",
+	})
+	assert.Equal(t, "PaymentRequired", restored.Category)
+	assert.Equal(t, 402, restored.Status)
+	assert.Equal(t, "NO_FUNDS", restored.Code)
+}
+
+func TestUnregisteredCategory(t *testing.T) {
+	restored := cerrors.ApplicationErrorFactory.Create(&cerrors.ErrorDescription{
+		Category: "Gone",
+		Status:   410,
+		Code:     "GONE",
+		Message:  "Resource is gone",
+	})
+	assert.Equal(t, "Gone", restored.Category)
+	assert.Equal(t, 410, restored.Status)
+}
+
+func TestReregisterKeepsRetryable(t *testing.T) {
+	cerrors.ErrorCategoryRegistry.Register("Throttled", 429, nil)
+	defer cerrors.ErrorCategoryRegistry.Unregister("Throttled")
+
+	cerrors.ErrorCategoryRegistry.SetRetryable("Throttled", true)
+	cerrors.ErrorCategoryRegistry.Register("Throttled", 503, nil)
+	assert.True(t, cerrors.ErrorCategoryRegistry.IsRetryable("Throttled"))
+
+	status, _ := cerrors.ErrorCategoryRegistry.GetStatus("Throttled")
+	assert.Equal(t, 503, status)
+}
+
+func TestRegisteredCategoryKeepsStatus(t *testing.T) {
+	err := cerrors.NewUnauthorizedError("123", "ACCESS_DENIED", "Access denied").WithStatus(403)
+	restored := cerrors.ApplicationErrorFactory.Create(cerrors.ErrorDescriptionFactory.Create(err))
+	assert.Equal(t, cerrors.Unauthorized, restored.Category)
+	assert.Equal(t, 403, restored.Status)
+
+	restored = cerrors.ApplicationErrorFactory.Create(&cerrors.ErrorDescription{
+		Category: cerrors.Unauthorized,
+		Code:     "NOT_AUTHENTICATED",
+	})
+	assert.Equal(t, 401, restored.Status)
+}
@@ -0,0 +1,60 @@
+package test_errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryToProtocolCodes(t *testing.T) {
+	mapping := cerrors.ErrorStatusMapping
+
+	assert.Equal(t, cerrors.GrpcNotFound, mapping.ToGrpcCode(cerrors.NotFound))
+	assert.Equal(t, cerrors.GrpcInvalidArgument, mapping.ToGrpcCode(cerrors.BadRequest))
+	assert.Equal(t, cerrors.GrpcUnknown, mapping.ToGrpcCode("Unregistered"))
+	assert.Equal(t, cerrors.ExitConfig, mapping.ToExitCode(cerrors.Misconfiguration))
+	assert.Equal(t, cerrors.ExitFailure, mapping.ToExitCode("Unregistered"))
+	assert.Equal(t, 409, mapping.ToHttpStatus(cerrors.Conflict))
+
+	err := fmt.Errorf("wrapped: %w", cerrors.NewUnauthorizedError("123", "NO_ACCESS", "No access"))
+	assert.Equal(t, cerrors.GrpcUnauthenticated, mapping.GrpcCodeOf(err))
+	assert.Equal(t, cerrors.ExitNoPerm, mapping.ExitCodeOf(err))
+	assert.Equal(t, 401, mapping.HttpStatusOf(err))
+
+	assert.Equal(t, cerrors.GrpcOk, mapping.GrpcCodeOf(nil))
+	assert.Equal(t, cerrors.ExitOk, mapping.ExitCodeOf(nil))
+	assert.Equal(t, cerrors.ExitFailure, mapping.ExitCodeOf(errors.New("Error")))
+	assert.Equal(t, 500, mapping.HttpStatusOf(errors.New("Error")))
+}
+
+func TestProtocolCodesToErrors(t *testing.T) {
+	mapping := cerrors.ErrorStatusMapping
+
+	for _, category := range []string{
+		cerrors.Unknown, cerrors.Internal, cerrors.InvalidState, cerrors.NoResponse,
+		cerrors.BadRequest, cerrors.Unauthorized, cerrors.Forbidden, cerrors.NotFound, cerrors.Conflict, cerrors.Unsupported,
+	} {
+		assert.Equal(t, category, mapping.FromGrpcCode(mapping.ToGrpcCode(category)), category)
+	}
+	assert.Equal(t, cerrors.NoResponse, mapping.FromGrpcCode(cerrors.GrpcDeadlineExceeded))
+	assert.Equal(t, cerrors.NoResponse, mapping.FromExitCode(cerrors.ExitTempFail))
+	assert.Equal(t, cerrors.Unknown, mapping.FromExitCode(3))
+	assert.Equal(t, cerrors.BadRequest, mapping.FromHttpStatus(422))
+	assert.Equal(t, cerrors.Forbidden, mapping.FromHttpStatus(403))
+	assert.Equal(t, cerrors.Forbidden, mapping.FromGrpcCode(cerrors.GrpcPermissionDenied))
+
+	err := mapping.CreateFromGrpcCode(cerrors.GrpcNotFound, "123", "NOT_FOUND", "Not found")
+	assert.Equal(t, cerrors.NotFound, err.Category)
+	assert.Equal(t, 404, err.Status)
+	assert.Nil(t, mapping.CreateFromGrpcCode(cerrors.GrpcOk, "123", "", ""))
+
+	err = mapping.CreateFromExitCode(cerrors.ExitUsage, "123", "USAGE", "Bad usage")
+	assert.Equal(t, cerrors.BadRequest, err.Category)
+
+	err = mapping.CreateFromHttpStatus(503, "123", "UNAVAILABLE", "Service unavailable")
+	assert.Equal(t, cerrors.NoResponse, err.Category)
+	assert.Equal(t, 503, err.Status)
+}
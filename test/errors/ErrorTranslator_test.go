@@ -0,0 +1,93 @@
+package test_errors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultTranslations(t *testing.T) {
+	err := cerrors.ErrorTranslator.Translate("123", fmt.Errorf("query failed: %w", context.DeadlineExceeded))
+	assert.Equal(t, cerrors.NoResponse, err.Category)
+	assert.Equal(t, "TIMEOUT", err.Code)
+	assert.Equal(t, "123", err.CorrelationId)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	err = cerrors.ErrorTranslator.Translate("123", context.Canceled)
+	assert.Equal(t, "CANCELED", err.Code)
+
+	_, e := os.Open("/not/existing/file")
+	err = cerrors.ErrorTranslator.Translate("123", e)
+	assert.Equal(t, cerrors.NotFound, err.Category)
+	assert.Equal(t, 404, err.Status)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	err = cerrors.ErrorTranslator.Translate("123", os.ErrPermission)
+	assert.Equal(t, cerrors.Unauthorized, err.Category)
+
+	err = cerrors.ErrorTranslator.Translate("123", io.EOF)
+	assert.Equal(t, "UNEXPECTED_EOF", err.Code)
+	assert.Equal(t, cerrors.NoResponse, err.Category)
+
+	err = cerrors.ErrorTranslator.Translate("123", &net.DNSError{Err: "timeout", IsTimeout: true})
+	assert.Equal(t, "TIMEOUT", err.Code)
+	err = cerrors.ErrorTranslator.Translate("123", &net.DNSError{Err: "no such host"})
+	assert.Equal(t, "CONNECTION_FAILED", err.Code)
+
+	var value map[string]any
+	e = json.Unmarshal([]byte("{bad json"), &value)
+	err = cerrors.ErrorTranslator.Translate("123", e)
+	assert.Equal(t, cerrors.BadRequest, err.Category)
+	assert.Equal(t, "INVALID_JSON", err.Code)
+	assert.Equal(t, int64(2), err.Details["offset"])
+
+	err = cerrors.ErrorTranslator.Translate("123", errors.New("Some error"))
+	assert.Equal(t, cerrors.Unknown, err.Category)
+	assert.Equal(t, "Some error", err.Message)
+
+	assert.Nil(t, cerrors.ErrorTranslator.Translate("123", nil))
+}
+
+var errNoRows = errors.New("no rows in result set")
+
+func TestCustomTranslationAndWrapError(t *testing.T) {
+	cerrors.ErrorTranslator.RegisterError(errNoRows, cerrors.NotFound, "NO_ROWS")
+	defer cerrors.ErrorTranslator.Reset()
+
+	err := cerrors.ErrorTranslator.Translate("123", errNoRows)
+	assert.Equal(t, "NO_ROWS", err.Code)
+
+	err = cerrors.WrapError(errNoRows, "Record not found")
+	assert.Equal(t, "UNKNOWN", err.Code)
+
+	cerrors.ErrorTranslator.SetTranslateInWrapError(true)
+
+	err = cerrors.WrapError(errNoRows, "Record not found")
+	assert.Equal(t, cerrors.NotFound, err.Category)
+	assert.Equal(t, "NO_ROWS", err.Code)
+	assert.Equal(t, "Record not found", err.Message)
+	assert.True(t, errors.Is(err, errNoRows))
+
+	err = cerrors.WrapError(errors.New("Other error"), "Failed")
+	assert.Equal(t, "UNKNOWN", err.Code)
+}
+
+func TestResetTranslations(t *testing.T) {
+	cerrors.ErrorTranslator.RegisterError(errNoRows, cerrors.NotFound, "NO_ROWS")
+	cerrors.ErrorTranslator.Reset()
+
+	err := cerrors.ErrorTranslator.Translate("123", errNoRows)
+	assert.Equal(t, "UNKNOWN", err.Code)
+	assert.False(t, cerrors.ErrorTranslator.IsTranslateInWrapError())
+
+	err = cerrors.ErrorTranslator.Translate("123", context.Canceled)
+	assert.Equal(t, "CANCELED", err.Code)
+}
@@ -0,0 +1,77 @@
+package test_errors
+
+import (
+	"encoding/json"
+	"testing"
+
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemDetailsRoundTrip(t *testing.T) {
+	err := cerrors.NewNotFoundError("123", "NO_ITEM", "Item not found").
+		WithDetails("id", "abc").
+		WithDetails("password", "secret")
+
+	problem := cerrors.ProblemDetailsFactory.Create(err)
+	assert.Equal(t, "urn:pip:error:NotFound:NO_ITEM", problem.Type)
+	assert.Equal(t, "Not Found", problem.Title)
+	assert.Equal(t, 404, problem.Status)
+
+	data, e := json.Marshal(problem)
+	assert.Nil(t, e)
+
+	var values map[string]any
+	json.Unmarshal(data, &values)
+	assert.Equal(t, "123", values["correlation_id"])
+	assert.Equal(t, "Item not found", values["detail"])
+	assert.NotContains(t, values, "instance")
+
+	var received cerrors.ProblemDetails
+	assert.Nil(t, json.Unmarshal(data, &received))
+
+	restored := cerrors.ProblemDetailsFactory.ToError(&received)
+	assert.Equal(t, cerrors.NotFound, restored.Category)
+	assert.Equal(t, "NO_ITEM", restored.Code)
+	assert.Equal(t, "123", restored.CorrelationId)
+	assert.Equal(t, "Item not found", restored.Message)
+	assert.Equal(t, 404, restored.Status)
+	assert.Equal(t, "abc", restored.Details["id"])
+	// Sensitive details are redacted
+	assert.NotEqual(t, "secret", restored.Details["password"])
+}
+
+func TestProblemDetailsTitleFromCatalog(t *testing.T) {
+	cerrors.ErrorCatalog.Register("PROBLEM_TITLE_TEST", cerrors.Conflict, 409, "Item already exists")
+	problem := cerrors.ProblemDetailsFactory.Create(
+		cerrors.NewConflictError("123", "PROBLEM_TITLE_TEST", "Item abc already exists"))
+	assert.Equal(t, "Item already exists", problem.Title)
+	assert.Equal(t, "Item abc already exists", problem.Detail)
+}
+
+func TestProblemDetailsFromForeignDocument(t *testing.T) {
+	data := `{"type":"https://example.com/probs/out-of-credit","title":"You do not have enough credit.",` +
+		`"status":409,"detail":"Your current balance is 30","balance":30}`
+
+	var problem cerrors.ProblemDetails
+	assert.Nil(t, json.Unmarshal([]byte(data), &problem))
+	assert.Equal(t, float64(30), problem.Extensions["balance"])
+
+	err := cerrors.ProblemDetailsFactory.ToError(&problem)
+	assert.Equal(t, cerrors.Conflict, err.Category)
+	assert.Equal(t, "Your current balance is 30", err.Message)
+	assert.Equal(t, 409, err.Status)
+
+	problem = cerrors.ProblemDetails{Type: "urn:pip:error:Unauthorized:NO_ACCESS", Title: "Access denied"}
+	err = cerrors.ProblemDetailsFactory.ToError(&problem)
+	assert.Equal(t, cerrors.Unauthorized, err.Category)
+	assert.Equal(t, "NO_ACCESS", err.Code)
+	assert.Equal(t, "Access denied", err.Message)
+
+	// Categories are resolved by the same table as ErrorStatusMapping
+	for _, status := range []int{403, 422, 500, 503} {
+		err = cerrors.ProblemDetailsFactory.ToError(&cerrors.ProblemDetails{Title: "Failed", Status: status})
+		assert.Equal(t, cerrors.ErrorStatusMapping.FromHttpStatus(status), err.Category)
+		assert.Equal(t, status, err.Status)
+	}
+}
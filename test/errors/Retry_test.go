@@ -0,0 +1,51 @@
+package test_errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryableDefaults(t *testing.T) {
+	assert.True(t, cerrors.NewConnectionError("123", "CODE", "Error").IsRetryable())
+	assert.True(t, cerrors.NewInvocationError("123", "CODE", "Error").IsRetryable())
+	assert.False(t, cerrors.NewConflictError("123", "CODE", "Error").IsRetryable())
+	assert.False(t, cerrors.NewBadRequestError("123", "CODE", "Error").IsRetryable())
+
+	assert.True(t, cerrors.NewConflictError("123", "CODE", "Error").WithRetryable(true).IsRetryable())
+	assert.False(t, cerrors.NewConnectionError("123", "CODE", "Error").WithRetryable(false).IsRetryable())
+}
+
+func TestIsRetryableThroughChain(t *testing.T) {
+	err := fmt.Errorf("call failed: %w", cerrors.NewConnectionError("123", "CODE", "Error"))
+	assert.True(t, cerrors.IsRetryable(err))
+
+	err = cerrors.NewInternalError("123", "CODE", "Error").
+		WithCause(cerrors.NewConflictError("123", "CODE", "Error").WithRetryable(true))
+	assert.True(t, cerrors.IsRetryable(err))
+
+	err = cerrors.WrapError(&net.DNSError{Err: "timeout", IsTimeout: true}, "Error")
+	assert.True(t, cerrors.IsRetryable(err))
+	assert.True(t, cerrors.IsRetryable(&net.DNSError{Err: "timeout", IsTimeout: true}))
+	assert.False(t, cerrors.IsRetryable(nil))
+}
+
+func TestRetryAfterSerialization(t *testing.T) {
+	err := cerrors.NewConflictError("123", "CODE", "Error").WithRetryable(true).WithRetryAfter(1500)
+
+	after, ok := cerrors.GetRetryAfter(fmt.Errorf("wrapped: %w", err))
+	assert.True(t, ok)
+	assert.Equal(t, int64(1500), after)
+
+	data, _ := json.Marshal(cerrors.ErrorDescriptionFactory.Create(err))
+	var d cerrors.ErrorDescription
+	json.Unmarshal(data, &d)
+
+	restored := cerrors.ApplicationErrorFactory.Create(&d)
+	assert.True(t, restored.IsRetryable())
+	assert.Equal(t, int64(1500), restored.RetryAfter)
+}
@@ -0,0 +1,65 @@
+package test_errors
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackTraceModes(t *testing.T) {
+	defer cerrors.StackTraceCapture.SetMode(cerrors.StackTraceOff)
+
+	err := cerrors.NewInternalError("123", "CODE", "Error message")
+	assert.Equal(t, "", err.StackTrace)
+
+	cerrors.StackTraceCapture.SetMode(cerrors.StackTraceInternal)
+	err = cerrors.NewInternalError("123", "CODE", "Error message")
+	assert.Contains(t, err.StackTrace, "TestStackTraceModes")
+	assert.NotContains(t, err.StackTrace, "errors.NewInternalError")
+
+	err = cerrors.NewBadRequestError("123", "CODE", "Error message")
+	assert.Equal(t, "", err.StackTrace)
+
+	cerrors.StackTraceCapture.SetMode(cerrors.StackTraceAlways)
+	err = cerrors.NewBadRequestError("123", "CODE", "Error message")
+	assert.Contains(t, err.StackTrace, "TestStackTraceModes")
+}
+
+func TestStackTraceFilter(t *testing.T) {
+	defer cerrors.StackTraceCapture.SetMode(cerrors.StackTraceOff)
+	defer cerrors.StackTraceCapture.SetFilter(nil)
+
+	cerrors.StackTraceCapture.SetMode(cerrors.StackTraceAlways)
+	cerrors.StackTraceCapture.SetFilter(func(frame runtime.Frame) bool {
+		return !strings.HasPrefix(frame.Function, "testing.")
+	})
+
+	err := cerrors.NewError("Error message")
+	assert.Contains(t, err.StackTrace, "TestStackTraceFilter")
+	assert.NotContains(t, err.StackTrace, "testing.tRunner")
+}
+
+func TestStackTracePropagation(t *testing.T) {
+	defer cerrors.StackTraceCapture.SetMode(cerrors.StackTraceOff)
+	cerrors.StackTraceCapture.SetMode(cerrors.StackTraceInternal)
+
+	err := cerrors.WrapError(errors.New("Cause error"), "Error message")
+	assert.NotEqual(t, "", err.StackTrace)
+
+	d := cerrors.ErrorDescriptionFactory.Create(err)
+	assert.Equal(t, err.StackTrace, d.StackTrace)
+
+	restored := cerrors.ApplicationErrorFactory.Create(d)
+	assert.Equal(t, err.StackTrace, restored.StackTrace)
+
+	output := fmt.Sprintf("%+v", err)
+	assert.Contains(t, output, "Error message [category= code=UNKNOWN status=500]")
+	assert.Contains(t, output, "TestStackTracePropagation")
+	assert.Contains(t, output, "Caused by: Cause error")
+	assert.Equal(t, "Error message", fmt.Sprintf("%v", err))
+}
@@ -0,0 +1,40 @@
+package test_run
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "", run.GetCorrelationId(ctx))
+	assert.Nil(t, run.GetPrincipal(ctx))
+
+	ctx = run.WithCorrelationId(ctx, "123")
+	ctx = run.WithTraceIds(ctx, "trace1", "span1")
+	ctx = run.WithTenant(ctx, "tenant1")
+	ctx = run.WithPrincipal(ctx, run.NewPrincipal("user1", "admin").WithName("User 1").WithClaim("scope", "orders"))
+
+	assert.Equal(t, "123", run.GetCorrelationId(ctx))
+	assert.Equal(t, "trace1", run.GetTraceId(ctx))
+	assert.Equal(t, "span1", run.GetSpanId(ctx))
+	assert.Equal(t, "tenant1", run.GetTenant(ctx))
+
+	principal := run.GetPrincipal(ctx)
+	assert.Equal(t, "user1", principal.Id)
+	assert.True(t, principal.HasRole("admin"))
+	assert.False(t, principal.HasRole("user"))
+	scope, ok := principal.GetClaim("scope")
+	assert.True(t, ok)
+	assert.Equal(t, "orders", scope)
+
+	// Empty values do not override existing ones
+	assert.Equal(t, "123", run.GetCorrelationId(run.WithCorrelationId(ctx, "")))
+	assert.Equal(t, "tenant1", run.GetTenant(run.WithTenant(ctx, "")))
+
+	var anonymous *run.Principal
+	assert.False(t, anonymous.HasRole("admin"))
+}
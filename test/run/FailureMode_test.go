@@ -0,0 +1,86 @@
+package test_run
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cerrors "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/run"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingComponent struct {
+	err    error
+	closed bool
+}
+
+func (c *failingComponent) Close(ctx context.Context, correlationId string) error {
+	c.closed = true
+	return c.err
+}
+
+func (c *failingComponent) Execute(ctx context.Context, correlationId string, args *run.Parameters) (any, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return "ok", nil
+}
+
+type namedFailingComponent struct {
+	failingComponent
+	name string
+}
+
+func (c *namedFailingComponent) Name() string {
+	return c.name
+}
+
+func TestCloseStopOnFailure(t *testing.T) {
+	err1 := errors.New("Error 1")
+	components := []any{&failingComponent{err: err1}, &failingComponent{}}
+
+	err := run.Closer.Close(context.Background(), "123", components)
+	assert.Equal(t, err1, err)
+	assert.False(t, components[1].(*failingComponent).closed)
+}
+
+func TestCloseContinueOnFailure(t *testing.T) {
+	err1 := errors.New("Error 1")
+	err2 := cerrors.NewConnectionError("123", "NO_CONNECTION", "No connection")
+	components := []any{&failingComponent{err: err1}, &failingComponent{}, &failingComponent{err: err2}}
+
+	err := run.Closer.CloseWithMode(context.Background(), "123", components, run.ContinueOnFailure)
+	assert.True(t, components[1].(*failingComponent).closed)
+	assert.True(t, errors.Is(err, err1))
+	assert.True(t, errors.Is(err, err2))
+
+	var appErr *cerrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "CLOSE_FAILED", appErr.Code)
+	assert.Len(t, appErr.Errors(), 2)
+
+	d := cerrors.ErrorDescriptionFactory.Create(err)
+	assert.Equal(t, "*test_run.failingComponent[0]", d.Errors[0].Details["component"])
+	assert.Equal(t, "*test_run.failingComponent[2]", d.Errors[1].Details["component"])
+
+	components = []any{&failingComponent{}, &namedFailingComponent{failingComponent{err: err1}, "db"}}
+	err = run.Closer.CloseWithMode(context.Background(), "123", components, run.ContinueOnFailure)
+	d = cerrors.ErrorDescriptionFactory.Create(err)
+	assert.Equal(t, "db[1]", d.Errors[0].Details["component"])
+
+	err = run.Closer.CloseWithMode(context.Background(), "123", []any{&failingComponent{}}, run.ContinueOnFailure)
+	assert.Nil(t, err)
+}
+
+func TestExecuteContinueOnFailure(t *testing.T) {
+	components := []any{&failingComponent{err: errors.New("Error 1")}, &failingComponent{}}
+
+	results, err := run.Executor.ExecuteWithMode(context.Background(), "123", components, nil, run.ContinueOnFailure)
+	assert.NotNil(t, err)
+	assert.Equal(t, []any{nil, "ok"}, results)
+
+	var appErr *cerrors.ApplicationError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "EXECUTE_FAILED", appErr.Code)
+}
@@ -0,0 +1,94 @@
+package test_validate
+
+import (
+	"encoding/json"
+	refl "reflect"
+	"testing"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonSchemaConverterObjectSchema(t *testing.T) {
+	schema := validate.NewObjectSchema().
+		WithRequiredProperty("id", convert.String, validate.NewValueComparisonRule("LIKE", "^[0-9]+$")).
+		WithOptionalProperty("count", convert.Integer,
+			validate.NewValueComparisonRule(">=", 0), validate.NewValueComparisonRule("<", 100)).
+		WithOptionalProperty("status", "string", validate.NewIncludedRule("new", "done")).
+		WithOptionalProperty("tags", validate.NewArraySchema(convert.String)).
+		WithOptionalProperty("labels", validate.NewMapSchema(convert.String, convert.Long)).
+		WithOptionalProperty("paging", validate.NewPagingParamsSchema())
+	schema.WithRule(validate.NewAtLeastOneExistsRule("count", "status"))
+
+	result := validate.JsonSchemaConverter.ToJsonSchemaWithStrict(schema, true)
+	buffer, err := json.Marshal(result)
+	assert.Nil(t, err)
+
+	expected := `{
+		"type": "object",
+		"additionalProperties": false,
+		"required": ["id"],
+		"anyOf": [{"required": ["count"]}, {"required": ["status"]}],
+		"properties": {
+			"id": {"type": "string", "pattern": "^[0-9]+$"},
+			"count": {"type": "integer", "format": "int32", "minimum": 0, "exclusiveMaximum": 100},
+			"status": {"type": "string", "enum": ["new", "done"]},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"labels": {"type": "object", "additionalProperties": {"type": "integer", "format": "int64"}},
+			"paging": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"skip": {"type": "integer", "format": "int64"},
+					"take": {"type": "integer", "format": "int64"},
+					"total": {"type": "boolean"}
+				}
+			}
+		}
+	}`
+	assert.JSONEq(t, expected, string(buffer))
+
+	// Undefined properties are only warnings in non-strict validation
+	result = validate.JsonSchemaConverter.ToJsonSchema(schema)
+	assert.NotContains(t, result, "additionalProperties")
+	paging := result["properties"].(map[string]any)["paging"].(map[string]any)
+	assert.NotContains(t, paging, "additionalProperties")
+}
+
+func TestJsonSchemaConverterRules(t *testing.T) {
+	schema := validate.NewSchema().
+		WithRule(validate.NewOrRule(
+			validate.NewValueComparisonRule("==", 1),
+			validate.NewNotRule(validate.NewExcludedRule(2, 3)),
+		)).
+		WithRule(validate.NewOnlyOneExistsRule("a", "b")).
+		WithRule(validate.NewPropertiesComparisonRule("a", "<", "b"))
+
+	result := validate.JsonSchemaConverter.ToJsonSchema(schema)
+	buffer, _ := json.Marshal(result)
+	expected := `{
+		"anyOf": [{"const": 1}, {"not": {"not": {"enum": [2, 3]}}}],
+		"oneOf": [{"required": ["a"]}, {"required": ["b"]}],
+		"x-compare": "a < b"
+	}`
+	assert.JSONEq(t, expected, string(buffer))
+}
+
+func TestJsonSchemaConverterReflectType(t *testing.T) {
+	result := validate.JsonSchemaConverter.ToJsonSchema(refl.TypeOf(structSchemaArgs{}))
+	buffer, _ := json.Marshal(result)
+	expected := `{
+		"type": "object",
+		"required": ["id", "name"],
+		"properties": {
+			"id": {"type": "string"},
+			"name": {"type": "string"},
+			"count": {"type": "integer", "format": "int32"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"created": {"type": "string", "format": "date-time"}
+		}
+	}`
+	assert.JSONEq(t, expected, string(buffer))
+}
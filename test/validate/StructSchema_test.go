@@ -0,0 +1,71 @@
+package test_validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+	"github.com/pip-services3-gox/pip-services3-commons-gox/validate"
+	"github.com/stretchr/testify/assert"
+)
+
+type structSchemaBase struct {
+	Id string `json:"id"`
+}
+
+type structSchemaArgs struct {
+	structSchemaBase
+	Name    string            `json:"name"`
+	Count   *int              `json:"count"`
+	Tags    []string          `json:"tags,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Created time.Time         `json:"created,omitempty"`
+	Ignored string            `json:"-"`
+	hidden  string
+}
+
+func TestNewObjectSchemaFromType(t *testing.T) {
+	schema := validate.NewObjectSchemaFromType(structSchemaArgs{})
+	assert.NotNil(t, schema)
+
+	properties := map[string]*validate.PropertySchema{}
+	for _, property := range schema.Properties() {
+		properties[property.Name()] = property
+	}
+	assert.Len(t, properties, 6)
+
+	assert.True(t, properties["id"].Required())
+	assert.Equal(t, convert.String, properties["id"].Type())
+	assert.True(t, properties["name"].Required())
+	assert.False(t, properties["count"].Required())
+	assert.Equal(t, convert.Integer, properties["count"].Type())
+	assert.False(t, properties["tags"].Required())
+	assert.IsType(t, &validate.ArraySchema{}, properties["tags"].Type())
+	assert.IsType(t, &validate.MapSchema{}, properties["labels"].Type())
+	assert.Equal(t, convert.DateTime, properties["created"].Type())
+
+	results := schema.Validate(map[string]any{"id": "1", "name": "ABC", "tags": []any{"a", "b"}})
+	assert.Len(t, results, 0)
+
+	results = schema.Validate(map[string]any{"id": "1", "count": "ABC"})
+	assert.Len(t, results, 2)
+
+	assert.Nil(t, validate.NewObjectSchemaFromType("ABC"))
+}
+
+type structSchemaScalars struct {
+	Active bool    `json:"active"`
+	Size   int     `json:"size"`
+	Ratio  float64 `json:"ratio"`
+	Name   string  `json:"name"`
+}
+
+func TestObjectSchemaFromTypeScalars(t *testing.T) {
+	schema := validate.NewObjectSchemaFromType(structSchemaScalars{})
+
+	results := schema.Validate(map[string]any{"name": "ABC"})
+	assert.Len(t, results, 0)
+
+	results = schema.Validate(map[string]any{"active": false, "size": 0})
+	assert.Len(t, results, 1)
+}
@@ -0,0 +1,8 @@
+package validate
+
+import "github.com/pip-services3-gox/pip-services3-commons-gox/errors"
+
+// Registers error codes raised by validation in the error catalog.
+func init() {
+	errors.ErrorCatalog.Register("INVALID_DATA", errors.BadRequest, 400, "Validation failed")
+}
@@ -0,0 +1,293 @@
+package validate
+
+import (
+	refl "reflect"
+	"strings"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+)
+
+// JsonSchemaConverter converts validation schemas into JSON Schema documents.
+//
+// ObjectSchema, ArraySchema, MapSchema and PropertySchema are converted into "object" and "array"
+// JSON schemas, TypeCodes, type names and reflect types into primitive JSON schemas.
+// Validation rules are converted into corresponding JSON Schema keywords:
+//
+//	ValueComparisonRule - minimum, maximum, exclusiveMinimum, exclusiveMaximum, const or pattern
+//	IncludedRule - enum
+//	ExcludedRule - not enum
+//	AndRule, OrRule, NotRule - allOf, anyOf, not
+//	AtLeastOneExistsRule, OnlyOneExistsRule - anyOf and oneOf of required properties
+//	PropertiesComparisonRule - "x-compare" extension, since JSON Schema has no equivalent
+//
+// Undefined properties are reported by ObjectSchema as warnings, so they are rejected only by strict validation.
+// For that reason "additionalProperties": false is emitted only by ToJsonSchemaWithStrict for strict validation.
+// Notice that property names in JSON Schema are case-sensitive, while ObjectSchema
+// and parameter binding match property names case-insensitively.
+//
+//	Example:
+//		schema := NewObjectSchema().
+//			WithRequiredProperty("id", convert.String).
+//			WithOptionalProperty("count", convert.Integer, NewValueComparisonRule(">=", 0))
+//
+//		jsonSchema := JsonSchemaConverter.ToJsonSchema(schema)
+//		// {"type": "object", "properties": {"id": {"type": "string"},
+//		//   "count": {"type": "integer", "format": "int32", "minimum": 0}}, "required": ["id"]}
+var JsonSchemaConverter = &_TJsonSchemaConverter{}
+
+type _TJsonSchemaConverter struct{}
+
+// ToJsonSchema converts a validation schema or a type into JSON Schema for non-strict validation.
+// Undefined properties are allowed.
+//	Parameters: schema any a validation schema, a TypeCode, a type name or a reflect.Type
+//	Returns: map[string]any JSON Schema
+func (c *_TJsonSchemaConverter) ToJsonSchema(schema any) map[string]any {
+	return c.toJsonSchema(schema, false, map[refl.Type]bool{})
+}
+
+// ToJsonSchemaWithStrict converts a validation schema or a type into JSON Schema.
+// In strict mode objects that do not allow undefined properties get "additionalProperties": false.
+//	Parameters:
+//		- schema any a validation schema, a TypeCode, a type name or a reflect.Type
+//		- strict bool true if the schema is used for strict validation that treats warnings as errors
+//	Returns: map[string]any JSON Schema
+func (c *_TJsonSchemaConverter) ToJsonSchemaWithStrict(schema any, strict bool) map[string]any {
+	return c.toJsonSchema(schema, strict, map[refl.Type]bool{})
+}
+
+func (c *_TJsonSchemaConverter) toJsonSchema(schema any, strict bool, visited map[refl.Type]bool) map[string]any {
+	switch s := schema.(type) {
+	case nil:
+		return map[string]any{}
+	case *PropertySchema:
+		return c.withRules(c.toJsonSchema(s.Type(), strict, visited), s.Rules())
+	case *ObjectSchema:
+		return c.withRules(c.objectToJsonSchema(s, strict, visited), s.Rules())
+	case *ArraySchema:
+		result := map[string]any{
+			"type":  "array",
+			"items": c.toJsonSchema(s.ValueType(), strict, visited),
+		}
+		return c.withRules(result, s.Rules())
+	case *MapSchema:
+		result := map[string]any{
+			"type":                 "object",
+			"additionalProperties": c.toJsonSchema(s.ValueType(), strict, visited),
+		}
+		return c.withRules(result, s.Rules())
+	case *Schema:
+		return c.withRules(map[string]any{}, s.Rules())
+	case convert.TypeCode:
+		return c.typeCodeToJsonSchema(s)
+	case string:
+		return c.typeNameToJsonSchema(s)
+	case refl.Type:
+		return c.reflectTypeToJsonSchema(s, strict, visited)
+	}
+
+	// Schemas that embed standard schemas
+	if s, ok := schema.(interface{ Rules() []IValidationRule }); ok {
+		return c.withRules(map[string]any{}, s.Rules())
+	}
+	return map[string]any{}
+}
+
+func (c *_TJsonSchemaConverter) objectToJsonSchema(schema *ObjectSchema, strict bool, visited map[refl.Type]bool) map[string]any {
+	result := map[string]any{
+		"type": "object",
+	}
+
+	properties := map[string]any{}
+	required := []string{}
+	for _, property := range schema.Properties() {
+		properties[property.Name()] = c.toJsonSchema(property, strict, visited)
+		if property.Required() {
+			required = append(required, property.Name())
+		}
+	}
+
+	if len(properties) > 0 {
+		result["properties"] = properties
+	}
+	if len(required) > 0 {
+		result["required"] = required
+	}
+	if strict && !schema.UndefinedAllowed() {
+		result["additionalProperties"] = false
+	}
+	return result
+}
+
+func (c *_TJsonSchemaConverter) typeCodeToJsonSchema(typ convert.TypeCode) map[string]any {
+	switch typ {
+	case convert.String:
+		return map[string]any{"type": "string"}
+	case convert.Boolean:
+		return map[string]any{"type": "boolean"}
+	case convert.Integer:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case convert.Long:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case convert.Float:
+		return map[string]any{"type": "number", "format": "float"}
+	case convert.Double:
+		return map[string]any{"type": "number", "format": "double"}
+	case convert.DateTime:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case convert.Duration:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case convert.Object, convert.Map:
+		return map[string]any{"type": "object"}
+	case convert.Array:
+		return map[string]any{"type": "array"}
+	default:
+		return map[string]any{}
+	}
+}
+
+func (c *_TJsonSchemaConverter) typeNameToJsonSchema(name string) map[string]any {
+	switch strings.ToLower(name) {
+	case "string":
+		return c.typeCodeToJsonSchema(convert.String)
+	case "bool", "boolean":
+		return c.typeCodeToJsonSchema(convert.Boolean)
+	case "int", "integer":
+		return c.typeCodeToJsonSchema(convert.Integer)
+	case "long":
+		return c.typeCodeToJsonSchema(convert.Long)
+	case "float":
+		return c.typeCodeToJsonSchema(convert.Float)
+	case "double":
+		return c.typeCodeToJsonSchema(convert.Double)
+	case "date", "datetime":
+		return c.typeCodeToJsonSchema(convert.DateTime)
+	case "timespan", "duration":
+		return c.typeCodeToJsonSchema(convert.Duration)
+	case "map", "dict", "dictionary":
+		return c.typeCodeToJsonSchema(convert.Map)
+	case "array", "list":
+		return c.typeCodeToJsonSchema(convert.Array)
+	default:
+		return map[string]any{}
+	}
+}
+
+func (c *_TJsonSchemaConverter) reflectTypeToJsonSchema(typ refl.Type, strict bool, visited map[refl.Type]bool) map[string]any {
+	for typ.Kind() == refl.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case refl.Interface:
+		return map[string]any{}
+	case refl.Struct:
+		code := convert.TypeConverter.ToTypeCode(typ)
+		if code != convert.Object || visited[typ] {
+			return c.typeCodeToJsonSchema(code)
+		}
+		visited[typ] = true
+		defer delete(visited, typ)
+		return c.toJsonSchema(NewObjectSchemaFromType(typ), strict, visited)
+	case refl.Slice, refl.Array:
+		if typ.Elem().Kind() == refl.Uint8 {
+			return c.typeCodeToJsonSchema(convert.String)
+		}
+		return map[string]any{
+			"type":  "array",
+			"items": c.toJsonSchema(typ.Elem(), strict, visited),
+		}
+	case refl.Map:
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": c.toJsonSchema(typ.Elem(), strict, visited),
+		}
+	default:
+		return c.typeCodeToJsonSchema(convert.TypeConverter.ToTypeCode(typ))
+	}
+}
+
+// withRules adds keywords for validation rules to JSON Schema.
+// Keywords that are already defined are combined using allOf.
+func (c *_TJsonSchemaConverter) withRules(result map[string]any, rules []IValidationRule) map[string]any {
+	for _, rule := range rules {
+		fragment := c.ruleToJsonSchema(rule)
+		if len(fragment) == 0 {
+			continue
+		}
+
+		conflict := false
+		for key := range fragment {
+			if _, ok := result[key]; ok {
+				conflict = true
+				break
+			}
+		}
+
+		if conflict {
+			allOf, _ := result["allOf"].([]any)
+			result["allOf"] = append(allOf, fragment)
+		} else {
+			for key, value := range fragment {
+				result[key] = value
+			}
+		}
+	}
+	return result
+}
+
+func (c *_TJsonSchemaConverter) rulesToJsonSchemas(rules []IValidationRule) []any {
+	result := make([]any, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, c.ruleToJsonSchema(rule))
+	}
+	return result
+}
+
+func (c *_TJsonSchemaConverter) requiredProperties(properties []string) []any {
+	result := make([]any, 0, len(properties))
+	for _, property := range properties {
+		result = append(result, map[string]any{"required": []string{property}})
+	}
+	return result
+}
+
+func (c *_TJsonSchemaConverter) ruleToJsonSchema(rule IValidationRule) map[string]any {
+	switch r := rule.(type) {
+	case *ValueComparisonRule:
+		switch strings.ToUpper(r.operation) {
+		case "=", "==", "EQ":
+			return map[string]any{"const": r.value}
+		case "!=", "<>", "NE":
+			return map[string]any{"not": map[string]any{"const": r.value}}
+		case "<", "LT":
+			return map[string]any{"exclusiveMaximum": r.value}
+		case "<=", "LE", "LTE":
+			return map[string]any{"maximum": r.value}
+		case ">", "GT":
+			return map[string]any{"exclusiveMinimum": r.value}
+		case ">=", "GE", "GTE":
+			return map[string]any{"minimum": r.value}
+		case "LIKE":
+			return map[string]any{"pattern": convert.StringConverter.ToString(r.value)}
+		}
+	case *IncludedRule:
+		return map[string]any{"enum": r.values}
+	case *ExcludedRule:
+		return map[string]any{"not": map[string]any{"enum": r.values}}
+	case *AndRule:
+		return map[string]any{"allOf": c.rulesToJsonSchemas(r.rules)}
+	case *OrRule:
+		return map[string]any{"anyOf": c.rulesToJsonSchemas(r.rules)}
+	case *NotRule:
+		if r.rule != nil {
+			return map[string]any{"not": c.ruleToJsonSchema(r.rule)}
+		}
+	case *AtLeastOneExistsRule:
+		return map[string]any{"anyOf": c.requiredProperties(r.properties)}
+	case *OnlyOneExistsRule:
+		return map[string]any{"oneOf": c.requiredProperties(r.properties)}
+	case *PropertiesComparisonRule:
+		return map[string]any{"x-compare": r.property1 + " " + r.operation + " " + r.property2}
+	}
+	return map[string]any{}
+}
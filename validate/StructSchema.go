@@ -0,0 +1,160 @@
+package validate
+
+import (
+	refl "reflect"
+	"strings"
+	"time"
+
+	"github.com/pip-services3-gox/pip-services3-commons-gox/convert"
+)
+
+// NewObjectSchemaFromType creates a validation schema for a struct type using its json tags.
+//
+// Property names are taken from json tags or field names. Fields tagged with "-" and
+// unexported fields are skipped, fields of embedded structs without tags are inlined.
+// Properties are required unless they are pointers, booleans, numbers or tagged with "omitempty".
+// Booleans and numbers are optional because their zero values are meaningful and
+// binding leaves them as zero when they are not passed. Use pointers to tell missing values apart.
+// Nested structs, slices and maps are described by ObjectSchema, ArraySchema and MapSchema,
+// other types are described by TypeCodes.
+//	Example:
+//		type MyArgs struct {
+//			Id     string   `json:"id"`
+//			Tags   []string `json:"tags,omitempty"`
+//			Count  *int     `json:"count"`
+//			Active bool     `json:"active"`
+//		}
+//
+//		schema := NewObjectSchemaFromType(MyArgs{})
+//		// Same as:
+//		// NewObjectSchema().
+//		//	WithRequiredProperty("id", convert.String).
+//		//	WithOptionalProperty("tags", NewArraySchema(convert.String)).
+//		//	WithOptionalProperty("count", convert.Integer).
+//		//	WithOptionalProperty("active", convert.Boolean)
+//	Parameters: typ any a struct value, a pointer to struct or its reflect.Type
+//	Returns: *ObjectSchema the derived schema or nil if the type is not a struct
+func NewObjectSchemaFromType(typ any) *ObjectSchema {
+	rt, ok := typ.(refl.Type)
+	if !ok {
+		if typ == nil {
+			return nil
+		}
+		rt = refl.TypeOf(typ)
+	}
+	for rt.Kind() == refl.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != refl.Struct {
+		return nil
+	}
+
+	return structToSchema(rt, map[refl.Type]bool{})
+}
+
+func structToSchema(rt refl.Type, visited map[refl.Type]bool) *ObjectSchema {
+	visited[rt] = true
+	defer delete(visited, rt)
+
+	schema := NewObjectSchema()
+	addStructProperties(schema, rt, visited)
+	return schema
+}
+
+func addStructProperties(schema *ObjectSchema, rt refl.Type, visited map[refl.Type]bool) {
+	for index := 0; index < rt.NumField(); index++ {
+		field := rt.Field(index)
+		name, omitEmpty, ok := ParseFieldTag(field)
+		if !ok {
+			continue
+		}
+
+		fieldType := field.Type
+		required := !omitEmpty && !isScalarKind(fieldType.Kind())
+		if fieldType.Kind() == refl.Ptr {
+			fieldType = fieldType.Elem()
+			required = false
+		}
+
+		if field.Anonymous && field.Tag.Get("json") == "" && fieldType.Kind() == refl.Struct {
+			addStructProperties(schema, fieldType, visited)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		schema.WithProperty(NewPropertySchemaWithRules(name, typeToSchema(fieldType, visited), required, nil))
+	}
+}
+
+func isScalarKind(kind refl.Kind) bool {
+	switch kind {
+	case refl.Bool,
+		refl.Int, refl.Int8, refl.Int16, refl.Int32, refl.Int64,
+		refl.Uint, refl.Uint8, refl.Uint16, refl.Uint32, refl.Uint64,
+		refl.Float32, refl.Float64:
+		return true
+	}
+	return false
+}
+
+func typeToSchema(rt refl.Type, visited map[refl.Type]bool) any {
+	for rt.Kind() == refl.Ptr {
+		rt = rt.Elem()
+	}
+
+	if rt == refl.TypeOf(time.Time{}) {
+		return convert.DateTime
+	}
+	if rt == refl.TypeOf(time.Duration(0)) {
+		return convert.Duration
+	}
+
+	switch rt.Kind() {
+	case refl.Interface:
+		return nil
+	case refl.Struct:
+		// Recursive types are not expanded
+		if visited[rt] {
+			return convert.Object
+		}
+		return structToSchema(rt, visited)
+	case refl.Slice, refl.Array:
+		if rt.Elem().Kind() == refl.Uint8 {
+			return convert.String
+		}
+		return NewArraySchema(typeToSchema(rt.Elem(), visited))
+	case refl.Map:
+		return NewMapSchema(typeToSchema(rt.Key(), visited), typeToSchema(rt.Elem(), visited))
+	default:
+		return convert.TypeConverter.ToTypeCode(rt)
+	}
+}
+
+// ParseFieldTag gets a property name of the struct field from its json tag.
+//	Parameters: field reflect.StructField a struct field
+//	Returns: the property name, true if the field is tagged with "omitempty"
+//		and false if the field is not serialized
+func ParseFieldTag(field refl.StructField) (name string, omitEmpty bool, ok bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false, false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, true
+}